@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
@@ -13,16 +12,21 @@ import (
 
 	"user-service/configs"
 	"user-service/internal/domain"
+	"user-service/internal/infrastructure/breach"
+	"user-service/internal/infrastructure/encryption"
+	"user-service/internal/infrastructure/hashing"
 	"user-service/internal/infrastructure/jwt"
 	"user-service/internal/infrastructure/logger"
+	"user-service/internal/infrastructure/notification"
+	"user-service/internal/infrastructure/outbox"
 	"user-service/internal/infrastructure/pld"
 	"user-service/internal/infrastructure/rabbitmq"
 	"user-service/internal/infrastructure/repository"
+	"user-service/internal/infrastructure/revocation"
 	httphandler "user-service/internal/interfaces/http"
 	"user-service/internal/interfaces/http/handlers"
 	"user-service/internal/usecase"
 
-	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -56,88 +60,411 @@ func main() {
 		appLogger.Fatal("Error al conectar a la base de datos", zap.Error(err))
 	}
 
-	if err := db.AutoMigrate(&domain.User{}, &domain.UserEvent{}); err != nil {
+	if err := db.AutoMigrate(&domain.User{}, &domain.UserEvent{}, &domain.RefreshToken{}, &domain.RevokedToken{}, &domain.UserTokenRevocation{}, &domain.OneTimeToken{}, &domain.LoginAttempt{}, &domain.RecoveryCode{}, &domain.PasswordHistoryEntry{}, &domain.Session{}, &domain.APIKey{}, &domain.OAuthClient{}, &domain.OutboxMessage{}); err != nil {
 		appLogger.Fatal("Error al migrar base de datos", zap.Error(err))
 	}
 	appLogger.Info("Base de datos migrada correctamente")
 
 	userRepo := repository.NewUserRepository(db)
 	userEventRepo := repository.NewUserEventRepository(db)
-
-	jwtService := jwt.NewJWTService(cfg.JWT.SecretKey, cfg.JWT.ExpiresIn)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
+	oneTimeTokenRepo := repository.NewOneTimeTokenRepository(db)
+	loginAttemptRepo := repository.NewLoginAttemptRepository(db)
+	recoveryCodeRepo := repository.NewRecoveryCodeRepository(db)
+	passwordHistoryRepo := repository.NewPasswordHistoryRepository(db)
+	sessionRepo := repository.NewSessionRepository(db)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	oauthClientRepo := repository.NewOAuthClientRepository(db)
+	outboxRepo := repository.NewOutboxRepository(db)
+	revocationStore := revocation.NewRevocationStore(db, cfg.JWT.RevocationCacheTTL)
+
+	var jwtService domain.JWTService
+	if cfg.JWT.KeysDir == "" {
+		jwtService, err = jwt.NewJWTService(cfg.JWT.SecretKey, cfg.JWT.Issuer, cfg.JWT.Audience, cfg.JWT.ExpiresIn, cfg.JWT.RefreshExpiresIn)
+		if err != nil {
+			appLogger.Fatal("Error al inicializar JWT", zap.Error(err))
+		}
+	} else {
+		keyring, err := jwt.LoadKeyring(cfg.JWT.KeysDir, cfg.JWT.ActiveKeyID, []byte(cfg.JWT.SecretKey))
+		if err != nil {
+			appLogger.Fatal("Error al cargar claves JWT", zap.Error(err))
+		}
+		jwtService = jwt.NewJWTServiceWithKeyring(keyring, cfg.JWT.Issuer, cfg.JWT.Audience, cfg.JWT.ExpiresIn, cfg.JWT.RefreshExpiresIn)
+		appLogger.Info("Keyring JWT cargado",
+			zap.String("active_kid", keyring.Active().ID),
+			zap.String("alg", keyring.Active().Method.Alg()),
+			zap.Int("keys", len(keyring.Keys())),
+		)
+	}
 	pldService := pld.NewPLDClient(cfg.PLD.BaseURL, cfg.PLD.Timeout, appLogger)
+	notifier := notification.NewLogNotifier(appLogger)
+
+	passwordHasher, err := hashing.NewPasswordHasher(hashing.Config{
+		Algorithm: cfg.PasswordHash.Algorithm,
+		Argon2id: hashing.Argon2idParams{
+			Memory:      uint32(cfg.PasswordHash.Argon2Memory),
+			Iterations:  uint32(cfg.PasswordHash.Argon2Iterations),
+			Parallelism: uint8(cfg.PasswordHash.Argon2Parallelism),
+		},
+		BcryptCost: cfg.PasswordHash.BcryptCost,
+	})
+	if err != nil {
+		appLogger.Fatal("Error al inicializar hash de contraseñas", zap.Error(err))
+	}
+
+	passwordPolicy := domain.PasswordPolicy{
+		MinLength:          cfg.PasswordPolicy.MinLength,
+		MaxLength:          cfg.PasswordPolicy.MaxLength,
+		RequireUppercase:   cfg.PasswordPolicy.RequireUppercase,
+		RequireLowercase:   cfg.PasswordPolicy.RequireLowercase,
+		RequireDigit:       cfg.PasswordPolicy.RequireDigit,
+		RequireSymbol:      cfg.PasswordPolicy.RequireSymbol,
+		RejectPersonalInfo: cfg.PasswordPolicy.RejectPersonalInfo,
+	}
+	if cfg.PasswordHash.Algorithm == hashing.AlgorithmBcrypt && (passwordPolicy.MaxLength <= 0 || passwordPolicy.MaxLength > domain.MaxPasswordLength) {
+		appLogger.Fatal("PASSWORD_MAX_LENGTH no puede superar 72 bytes con bcrypt", zap.Int("max_length", passwordPolicy.MaxLength))
+	}
+
+	var breachedChecker domain.BreachedPasswordChecker
+	if cfg.PasswordPolicy.BreachedCorpus != "" {
+		corpus, err := breach.LoadCorpus(cfg.PasswordPolicy.BreachedCorpus)
+		if err != nil {
+			appLogger.Fatal("Error al cargar corpus de contraseñas filtradas", zap.Error(err))
+		}
+		breachedChecker = corpus
+		appLogger.Info("Corpus de contraseñas filtradas cargado", zap.Int("hashes", corpus.Size()))
+	}
+
+	passwordValidator := usecase.NewPasswordValidator(passwordPolicy, breachedChecker)
+	rotationPolicy := domain.PasswordRotationPolicy{
+		HistorySize: cfg.PasswordPolicy.HistorySize,
+		MaxAge:      time.Duration(cfg.PasswordPolicy.MaxAgeDays) * 24 * time.Hour,
+	}
+	passwordHistory := usecase.NewPasswordHistory(passwordHistoryRepo, passwordHasher, rotationPolicy.HistorySize)
 
-	eventPublisher, err := rabbitmq.NewEventPublisher(cfg.RabbitMQ.URL, "user.created")
+	mfaCipher, err := encryption.NewAESGCMCipher(cfg.Auth.MFAEncryptionKey)
+	if err != nil {
+		appLogger.Fatal("Error al inicializar cifrado de secretos MFA", zap.Error(err))
+	}
+
+	eventPublisher, err := rabbitmq.NewEventPublisher(cfg.RabbitMQ.URL, domain.EventUserCreated, rabbitmq.PublisherConfig{
+		BufferSize:        cfg.RabbitMQ.PublishBuffer,
+		ReconnectDelay:    time.Duration(cfg.RabbitMQ.ReconnectDelay) * time.Second,
+		MaxReconnectDelay: time.Duration(cfg.RabbitMQ.MaxReconnectDelay) * time.Second,
+	})
 	if err != nil {
 		appLogger.Fatal("Error al inicializar publisher de RabbitMQ", zap.Error(err))
 	}
 	appLogger.Info("Publisher de RabbitMQ inicializado")
 
-	eventConsumer, err := rabbitmq.NewEventConsumer(cfg.RabbitMQ.URL, "user.created")
+	eventConsumer, err := rabbitmq.NewEventConsumer(cfg.RabbitMQ.URL, domain.EventUserCreated, rabbitmq.ConsumerConfig{
+		Prefetch:          cfg.RabbitMQ.Prefetch,
+		Workers:           cfg.RabbitMQ.ConsumerWorkers,
+		ReconnectDelay:    time.Duration(cfg.RabbitMQ.ReconnectDelay) * time.Second,
+		MaxReconnectDelay: time.Duration(cfg.RabbitMQ.MaxReconnectDelay) * time.Second,
+		Retry: rabbitmq.RetryPolicy{
+			MaxAttempts: cfg.RabbitMQ.MaxAttempts,
+			BaseDelay:   time.Duration(cfg.RabbitMQ.RetryBaseDelay) * time.Second,
+			MaxDelay:    time.Duration(cfg.RabbitMQ.RetryMaxDelay) * time.Second,
+		},
+	})
 	if err != nil {
 		appLogger.Fatal("Error al inicializar consumer de RabbitMQ", zap.Error(err))
 	}
 	appLogger.Info("Consumer de RabbitMQ inicializado")
 
+	verificationPolicy := usecase.EmailVerificationPolicy{
+		Required:        cfg.Auth.RequireEmailVerification,
+		TokenTTLMinutes: cfg.Auth.EmailVerificationTTL,
+	}
+
 	createUserUseCase := usecase.NewCreateUserUseCase(
 		userRepo,
+		refreshTokenRepo,
+		sessionRepo,
+		oneTimeTokenRepo,
 		pldService,
-		eventPublisher,
+		notifier,
 		jwtService,
+		passwordHasher,
+		passwordValidator,
+		passwordHistory,
+		verificationPolicy,
+		usecase.SignupConcealment{
+			Enabled:     cfg.Auth.SignupConcealExisting,
+			MinDuration: time.Duration(cfg.Auth.SignupConcealMinDuration) * time.Millisecond,
+		},
 	)
 
+	accountLockout := domain.LockoutPolicy{
+		MaxFailures:     cfg.Auth.LoginMaxFailures,
+		LockoutDuration: time.Duration(cfg.Auth.LoginLockoutDuration) * time.Minute,
+		BaseDelay:       time.Duration(cfg.Auth.LoginBackoffBase) * time.Second,
+		MaxDelay:        time.Duration(cfg.Auth.LoginBackoffMax) * time.Second,
+	}
+	ipLockout := accountLockout
+	ipLockout.MaxFailures = cfg.Auth.LoginIPMaxFailures
+
 	loginUseCase := usecase.NewLoginUseCase(
 		userRepo,
+		refreshTokenRepo,
+		sessionRepo,
+		oneTimeTokenRepo,
+		loginAttemptRepo,
+		eventPublisher,
+		jwtService,
+		passwordHasher,
+		verificationPolicy,
+		accountLockout,
+		ipLockout,
+		rotationPolicy,
+	)
+
+	refreshTokenUseCase := usecase.NewRefreshTokenUseCase(
+		userRepo,
+		refreshTokenRepo,
+		sessionRepo,
+		revocationStore,
 		jwtService,
 	)
 
 	getUserUseCase := usecase.NewGetUserUseCase(userRepo)
 
+	updateProfileUseCase := usecase.NewUpdateProfileUseCase(
+		userRepo,
+		pldService,
+		eventPublisher,
+	)
+
+	changePasswordUseCase := usecase.NewChangePasswordUseCase(
+		userRepo,
+		refreshTokenRepo,
+		sessionRepo,
+		revocationStore,
+		eventPublisher,
+		jwtService,
+		passwordHasher,
+		passwordValidator,
+		passwordHistory,
+	)
+
+	enrollTOTPUseCase := usecase.NewEnrollTOTPUseCase(userRepo, mfaCipher, cfg.Auth.MFAIssuer)
+
+	confirmTOTPUseCase := usecase.NewConfirmTOTPUseCase(userRepo, recoveryCodeRepo, mfaCipher)
+
+	listSessionsUseCase := usecase.NewListSessionsUseCase(sessionRepo)
+
+	revokeSessionUseCase := usecase.NewRevokeSessionUseCase(
+		sessionRepo,
+		refreshTokenRepo,
+		revocationStore,
+	)
+
 	userHandler := handlers.NewUserHandler(
 		createUserUseCase,
 		loginUseCase,
 		getUserUseCase,
+		updateProfileUseCase,
+		changePasswordUseCase,
+		enrollTOTPUseCase,
+		confirmTOTPUseCase,
+		listSessionsUseCase,
+		revokeSessionUseCase,
 	)
 
-	router := httphandler.SetupRouter(userHandler, jwtService)
+	logoutUseCase := usecase.NewLogoutUseCase(
+		refreshTokenRepo,
+		revocationStore,
+	)
+
+	logoutAllUseCase := usecase.NewLogoutAllUseCase(
+		refreshTokenRepo,
+		revocationStore,
+	)
+
+	forgotPasswordUseCase := usecase.NewForgotPasswordUseCase(
+		userRepo,
+		oneTimeTokenRepo,
+		notifier,
+		cfg.Auth.PasswordResetTTL,
+	)
+
+	resetPasswordUseCase := usecase.NewResetPasswordUseCase(
+		userRepo,
+		oneTimeTokenRepo,
+		refreshTokenRepo,
+		revocationStore,
+		eventPublisher,
+		passwordHasher,
+		passwordValidator,
+		passwordHistory,
+	)
+
+	verifyEmailUseCase := usecase.NewVerifyEmailUseCase(userRepo, oneTimeTokenRepo)
+
+	resendVerificationUseCase := usecase.NewResendVerificationUseCase(
+		userRepo,
+		oneTimeTokenRepo,
+		notifier,
+		verificationPolicy,
+	)
+
+	verifyMFAUseCase := usecase.NewVerifyMFAUseCase(
+		userRepo,
+		oneTimeTokenRepo,
+		recoveryCodeRepo,
+		refreshTokenRepo,
+		sessionRepo,
+		loginAttemptRepo,
+		mfaCipher,
+		jwtService,
+		accountLockout,
+		rotationPolicy,
+	)
+
+	authHandler := handlers.NewAuthHandler(
+		refreshTokenUseCase,
+		logoutUseCase,
+		logoutAllUseCase,
+		forgotPasswordUseCase,
+		resetPasswordUseCase,
+		verifyEmailUseCase,
+		resendVerificationUseCase,
+		verifyMFAUseCase,
+	)
+
+	listUsersUseCase := usecase.NewListUsersUseCase(userRepo)
+
+	updateUserStatusUseCase := usecase.NewUpdateUserStatusUseCase(
+		userRepo,
+		refreshTokenRepo,
+		revocationStore,
+	)
+
+	deleteUserUseCase := usecase.NewDeleteUserUseCase(
+		userRepo,
+		refreshTokenRepo,
+		revocationStore,
+	)
+
+	unlockUserUseCase := usecase.NewUnlockUserUseCase(userRepo, loginAttemptRepo)
+
+	adminHandler := handlers.NewAdminHandler(
+		listUsersUseCase,
+		getUserUseCase,
+		updateUserStatusUseCase,
+		deleteUserUseCase,
+		unlockUserUseCase,
+	)
+
+	createAPIKeyUseCase := usecase.NewCreateAPIKeyUseCase(userRepo, apiKeyRepo)
+
+	listAPIKeysUseCase := usecase.NewListAPIKeysUseCase(apiKeyRepo)
+
+	revokeAPIKeyUseCase := usecase.NewRevokeAPIKeyUseCase(apiKeyRepo)
+
+	apiKeyHandler := handlers.NewAPIKeyHandler(
+		createAPIKeyUseCase,
+		listAPIKeysUseCase,
+		revokeAPIKeyUseCase,
+	)
+
+	apiKeyAuthenticator := usecase.NewAPIKeyAuthenticator(userRepo, apiKeyRepo)
+
+	clientCredentialsUseCase := usecase.NewClientCredentialsUseCase(
+		oauthClientRepo,
+		jwtService,
+		time.Duration(cfg.JWT.ExpiresIn)*time.Minute,
+	)
+
+	createOAuthClientUseCase := usecase.NewCreateOAuthClientUseCase(oauthClientRepo)
+
+	listOAuthClientsUseCase := usecase.NewListOAuthClientsUseCase(oauthClientRepo)
+
+	disableOAuthClientUseCase := usecase.NewDisableOAuthClientUseCase(oauthClientRepo)
+
+	recordUserCreatedUseCase := usecase.NewRecordUserCreatedUseCase(userEventRepo)
+
+	oauthHandler := handlers.NewOAuthHandler(
+		clientCredentialsUseCase,
+		createOAuthClientUseCase,
+		listOAuthClientsUseCase,
+		disableOAuthClientUseCase,
+	)
+
+	wellKnownHandler := handlers.NewWellKnownHandler(
+		jwtService,
+		cfg.JWT.Issuer,
+		cfg.JWT.JWKSMaxAge,
+	)
+
+	router := httphandler.SetupRouter(userHandler, authHandler, adminHandler, apiKeyHandler, oauthHandler, wellKnownHandler, jwtService, revocationStore, apiKeyAuthenticator)
+	// Sin proxies de confianza, ClientIP ignora X-Forwarded-For: si no, cualquiera
+	// podría cambiar de IP en cada intento de login o bloquear la de otro.
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		appLogger.Fatal("TRUSTED_PROXIES inválido", zap.Error(err))
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	if cfg.Outbox.PollInterval <= 0 || cfg.Outbox.BatchSize <= 0 {
+		appLogger.Fatal("OUTBOX_POLL_INTERVAL y OUTBOX_BATCH_SIZE deben ser mayores que 0")
+	}
+	outboxRelay := outbox.NewRelay(outboxRepo, eventPublisher, appLogger, outbox.Config{
+		PollInterval:   time.Duration(cfg.Outbox.PollInterval) * time.Second,
+		BatchSize:      cfg.Outbox.BatchSize,
+		PublishTimeout: 10 * time.Second,
+		Lease:          30 * time.Second,
+		BaseBackoff:    time.Second,
+		MaxBackoff:     time.Duration(cfg.Outbox.MaxBackoff) * time.Second,
+		StatsInterval:  time.Minute,
+		StuckAfter:     time.Duration(cfg.Outbox.StuckAfter) * time.Second,
+		Retention:      time.Duration(cfg.Outbox.Retention) * time.Hour,
+	})
+	relayDone := make(chan struct{})
 	go func() {
+		defer close(relayDone)
+		appLogger.Info("Iniciando relay del outbox")
+		outboxRelay.Run(ctx)
+	}()
+
+	if cfg.Server.MetricsAddr != "" {
+		// expvar registra /debug/vars en http.DefaultServeMux
+		go func() {
+			appLogger.Info("Métricas disponibles", zap.String("address", cfg.Server.MetricsAddr))
+			if err := http.ListenAndServe(cfg.Server.MetricsAddr, http.DefaultServeMux); err != nil {
+				appLogger.Error("Error al servir métricas", zap.Error(err))
+			}
+		}()
+	}
+
+	consumerDone := make(chan struct{})
+	go func() {
+		defer close(consumerDone)
 		appLogger.Info("Iniciando consumidor de eventos")
-		handler := func(userID, email string, createdAt int64) error {
+		handler := func(ctx context.Context, envelope *domain.EventEnvelope, payload *domain.UserCreatedPayload) error {
 			appLogger.Info("Procesando evento user.created",
-				zap.String("user_id", userID),
-				zap.String("email", email),
-				zap.Int64("created_at", createdAt),
+				zap.String("event_id", envelope.ID),
+				zap.Int("schema_version", envelope.SchemaVersion),
+				zap.String("correlation_id", envelope.CorrelationID),
+				zap.String("user_id", payload.UserID),
+				zap.String("email", payload.Email),
+				zap.Time("created_at", payload.CreatedAt),
 			)
-			
+
 			appLogger.Info("Enviando email de bienvenida",
-				zap.String("user_id", userID),
-				zap.String("email", email),
+				zap.String("user_id", payload.UserID),
+				zap.String("email", payload.Email),
 			)
 
-			eventPayload, _ := json.Marshal(map[string]interface{}{
-				"user_id":    userID,
-				"email":      email,
-				"created_at": time.Unix(createdAt, 0).Format(time.RFC3339),
-			})
-
-			userUUID, err := uuid.Parse(userID)
-			if err != nil {
-				appLogger.Warn("Error al parsear UUID", zap.Error(err))
-				return nil
-			}
-
-			event := &domain.UserEvent{
-				UserID:    userUUID,
-				EventType: "user.created",
-				Payload:   eventPayload,
-			}
-
-			if err := userEventRepo.Create(context.Background(), event); err != nil {
-				appLogger.Warn("Error al guardar evento en auditoría", zap.Error(err))
+			// El error decide si el mensaje se reintenta o se aparca
+			if err := recordUserCreatedUseCase.Execute(ctx, payload); err != nil {
+				appLogger.Warn("Error al procesar evento user.created",
+					zap.String("event_id", envelope.ID),
+					zap.Error(err),
+				)
+				return err
 			}
 
 			return nil
@@ -172,12 +499,33 @@ func main() {
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer shutdownCancel()
 
+	// Detiene el consumidor y espera a los handlers en curso antes de cerrar
+	// el servidor HTTP
 	cancel()
+	select {
+	case <-consumerDone:
+		appLogger.Info("Consumidor de eventos detenido")
+	case <-shutdownCtx.Done():
+		appLogger.Warn("Tiempo agotado esperando al consumidor de eventos")
+	}
+	if err := eventConsumer.Close(); err != nil {
+		appLogger.Error("Error al cerrar consumidor de eventos", zap.Error(err))
+	}
 
 	if err := srv.Shutdown(shutdownCtx); err != nil {
 		appLogger.Error("Error al cerrar servidor", zap.Error(err))
 	}
 
+	// Sin nadie más que publique, se esperan las confirmaciones pendientes
+	select {
+	case <-relayDone:
+	case <-shutdownCtx.Done():
+		appLogger.Warn("Tiempo agotado esperando al relay del outbox")
+	}
+	if err := eventPublisher.Close(shutdownCtx); err != nil {
+		appLogger.Error("Error al cerrar publisher de eventos", zap.Error(err))
+	}
+
 	appLogger.Info("Servidor cerrado")
 }
 
@@ -0,0 +1,91 @@
+// Comando dlq: inspecciona y reenvía los mensajes aparcados de una cola de
+// eventos.
+//
+//	dlq [-queue user.created] list [-limit 20]
+//	dlq [-queue user.created] replay [-limit 100]
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"user-service/configs"
+	"user-service/internal/domain"
+	"user-service/internal/infrastructure/rabbitmq"
+)
+
+func main() {
+	flag.Usage = usage
+	queueName := flag.String("queue", domain.EventUserCreated, "cola de eventos")
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		usage()
+		os.Exit(2)
+	}
+
+	cfg, err := configs.Load()
+	if err != nil {
+		log.Fatalf("Error al cargar configuración: %v", err)
+	}
+
+	admin, err := rabbitmq.NewDeadLetterAdmin(cfg.RabbitMQ.URL, *queueName)
+	if err != nil {
+		log.Fatalf("Error al inicializar RabbitMQ: %v", err)
+	}
+	defer admin.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	command, args := flag.Arg(0), flag.Args()[1:]
+	switch command {
+	case "list":
+		fs := flag.NewFlagSet("list", flag.ExitOnError)
+		limit := fs.Int("limit", 20, "mensajes a mostrar")
+		fs.Parse(args)
+
+		messages, err := admin.List(*limit)
+		if err != nil {
+			log.Fatalf("Error al listar mensajes aparcados: %v", err)
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(messages); err != nil {
+			log.Fatalf("Error al mostrar mensajes: %v", err)
+		}
+	case "replay":
+		fs := flag.NewFlagSet("replay", flag.ExitOnError)
+		limit := fs.Int("limit", 100, "mensajes a reenviar")
+		fs.Parse(args)
+
+		replayed, err := admin.Replay(ctx, *limit)
+		fmt.Printf("%d mensajes reenviados a %s\n", replayed, *queueName)
+		if err != nil {
+			log.Fatalf("Error al reenviar mensajes aparcados: %v", err)
+		}
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `Uso: dlq [-queue cola] <comando> [opciones]
+
+Comandos:
+  list   [-limit 20]   muestra los mensajes aparcados sin sacarlos de la cola
+  replay [-limit 100]  reenvía los mensajes aparcados a la cola original
+
+Opciones:
+`)
+	flag.PrintDefaults()
+}
+
@@ -2,22 +2,30 @@ package configs
 
 import (
 	"fmt"
+	"log"
 	"os"
+	"strings"
 
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	PLD      PLDConfig
-	RabbitMQ RabbitMQConfig
+	Server         ServerConfig
+	Database       DatabaseConfig
+	JWT            JWTConfig
+	Auth           AuthConfig
+	PasswordHash   PasswordHashConfig
+	PasswordPolicy PasswordPolicyConfig
+	PLD            PLDConfig
+	RabbitMQ       RabbitMQConfig
+	Outbox         OutboxConfig
 }
 
 type ServerConfig struct {
-	Port string
-	Host string
+	Port           string
+	Host           string
+	MetricsAddr    string   // dirección donde se sirve /debug/vars; vacío no la sirve
+	TrustedProxies []string // IPs o CIDRs de proxies cuyo X-Forwarded-For se acepta; vacío usa la IP de la conexión
 }
 
 type DatabaseConfig struct {
@@ -30,8 +38,51 @@ type DatabaseConfig struct {
 }
 
 type JWTConfig struct {
-	SecretKey string
-	ExpiresIn int
+	SecretKey          string
+	ExpiresIn          int    // minutos de vida del access token
+	RefreshExpiresIn   int    // horas de vida del refresh token
+	RevocationCacheTTL int    // segundos que se cachea la lista de revocación
+	KeysDir            string // directorio con claves PEM; vacío usa HS256 con SecretKey
+	ActiveKeyID        string // kid (nombre de archivo sin .pem) de la clave de firma
+	Issuer             string // URL pública del servicio, usada como iss y en el discovery
+	Audience           string // valor de aud; vacío no emite ni valida aud
+	JWKSMaxAge         int    // segundos que los verificadores pueden cachear el JWKS
+}
+
+type AuthConfig struct {
+	PasswordResetTTL         int    // minutos de vigencia del token de restablecimiento
+	EmailVerificationTTL     int    // minutos de vigencia del token de verificación de email
+	RequireEmailVerification bool   // impide el login hasta verificar el email
+	LoginMaxFailures         int    // fallos por email antes del bloqueo temporal
+	LoginIPMaxFailures       int    // fallos por IP antes del bloqueo temporal
+	LoginLockoutDuration     int    // minutos de bloqueo; también ventana para olvidar fallos
+	LoginBackoffBase         int    // segundos de espera tras el primer fallo; se duplica en cada fallo
+	LoginBackoffMax          int    // segundos máximos de espera entre intentos
+	SignupConcealExisting    bool   // el alta responde 202 sin revelar si el email ya existe
+	SignupConcealMinDuration int    // milisegundos mínimos de un alta ocultada, para que el tiempo no revele el email
+	MFAEncryptionKey         string // clave con la que se cifran los secretos TOTP
+	MFAIssuer                string // nombre que muestran las apps autenticadoras
+}
+
+type PasswordHashConfig struct {
+	Algorithm         string // argon2id o bcrypt; los hashes de otro algoritmo se migran en el login
+	Argon2Memory      int    // KiB de memoria por hash argon2id
+	Argon2Iterations  int    // pasadas de argon2id
+	Argon2Parallelism int    // hilos de argon2id
+	BcryptCost        int    // coste de bcrypt
+}
+
+type PasswordPolicyConfig struct {
+	MinLength          int    // caracteres mínimos
+	MaxLength          int    // bytes máximos; con bcrypt no puede superar 72
+	RequireUppercase   bool   // exige al menos una mayúscula
+	RequireLowercase   bool   // exige al menos una minúscula
+	RequireDigit       bool   // exige al menos un número
+	RequireSymbol      bool   // exige al menos un símbolo o espacio
+	RejectPersonalInfo bool   // rechaza contraseñas que contengan el email o el nombre
+	BreachedCorpus     string // archivo de SHA-1 de contraseñas filtradas; vacío desactiva la comprobación
+	HistorySize        int    // últimas contraseñas, incluida la actual, que no pueden repetirse; 0 desactiva
+	MaxAgeDays         int    // días de vigencia antes de exigir el cambio; 0 desactiva
 }
 
 type PLDConfig struct {
@@ -40,11 +91,27 @@ type PLDConfig struct {
 }
 
 type RabbitMQConfig struct {
-	URL      string
-	User     string
-	Password string
-	Host     string
-	Port     string
+	URL               string
+	User              string
+	Password          string
+	Host              string
+	Port              string
+	PublishBuffer     int // publicaciones que esperan mientras se reconecta; las demás fallan
+	Prefetch          int // mensajes que el consumidor recibe por adelantado sin confirmar
+	ConsumerWorkers   int // mensajes que el consumidor procesa en paralelo
+	MaxAttempts       int // intentos de procesar un mensaje antes de aparcarlo
+	RetryBaseDelay    int // segundos antes del primer reintento; se duplica en cada fallo
+	RetryMaxDelay     int // segundos máximos antes de un reintento
+	ReconnectDelay    int // segundos de espera tras el primer intento de reconexión fallido
+	MaxReconnectDelay int // segundos máximos entre intentos de reconexión
+}
+
+type OutboxConfig struct {
+	PollInterval int // segundos entre búsquedas de eventos pendientes
+	BatchSize    int // eventos publicados por consulta
+	MaxBackoff   int // segundos máximos entre reintentos de un evento
+	StuckAfter   int // segundos sin publicarse tras los que un evento se reporta como atascado
+	Retention    int // horas que se conservan los eventos enviados; 0 no los purga
 }
 
 func Load() (*Config, error) {
@@ -57,6 +124,8 @@ func Load() (*Config, error) {
 
 	viper.SetDefault("SERVER_PORT", "8080")
 	viper.SetDefault("SERVER_HOST", "0.0.0.0")
+	viper.SetDefault("METRICS_ADDR", "")
+	viper.SetDefault("TRUSTED_PROXIES", "")
 	viper.SetDefault("DB_HOST", "localhost")
 	viper.SetDefault("DB_PORT", "5432")
 	viper.SetDefault("DB_USER", "postgres")
@@ -64,13 +133,59 @@ func Load() (*Config, error) {
 	viper.SetDefault("DB_NAME", "crabi_db")
 	viper.SetDefault("DB_SSLMODE", "disable")
 	viper.SetDefault("JWT_SECRET_KEY", "your-secret-key-change-in-production")
-	viper.SetDefault("JWT_EXPIRES_IN", 24)
+	viper.SetDefault("JWT_REFRESH_EXPIRES_IN", 720)
+	viper.SetDefault("JWT_REVOCATION_CACHE_TTL", 30)
+	viper.SetDefault("JWT_KEYS_DIR", "")
+	viper.SetDefault("JWT_ACTIVE_KEY_ID", "")
+	viper.SetDefault("JWT_ISSUER", "http://localhost:8080")
+	viper.SetDefault("JWT_JWKS_MAX_AGE", 300)
+	viper.SetDefault("JWT_AUDIENCE", "user-service")
+	viper.SetDefault("PASSWORD_RESET_TTL", 30)
+	viper.SetDefault("EMAIL_VERIFICATION_TTL", 1440)
+	viper.SetDefault("REQUIRE_EMAIL_VERIFICATION", false)
+	viper.SetDefault("LOGIN_MAX_FAILURES", 5)
+	viper.SetDefault("LOGIN_IP_MAX_FAILURES", 20)
+	viper.SetDefault("LOGIN_LOCKOUT_DURATION", 15)
+	viper.SetDefault("LOGIN_BACKOFF_BASE", 1)
+	viper.SetDefault("LOGIN_BACKOFF_MAX", 30)
+	viper.SetDefault("SIGNUP_CONCEAL_EXISTING", false)
+	viper.SetDefault("SIGNUP_CONCEAL_MIN_DURATION", 500)
+	viper.SetDefault("MFA_ENCRYPTION_KEY", "change-me-mfa-encryption-key")
+	viper.SetDefault("MFA_ISSUER", "Crabi")
+	viper.SetDefault("PASSWORD_HASH_ALGORITHM", "argon2id")
+	viper.SetDefault("ARGON2_MEMORY", 65536)
+	viper.SetDefault("ARGON2_ITERATIONS", 3)
+	viper.SetDefault("ARGON2_PARALLELISM", 2)
+	viper.SetDefault("BCRYPT_COST", 10)
+	viper.SetDefault("PASSWORD_MIN_LENGTH", 8)
+	viper.SetDefault("PASSWORD_MAX_LENGTH", 72)
+	viper.SetDefault("PASSWORD_REQUIRE_UPPERCASE", false)
+	viper.SetDefault("PASSWORD_REQUIRE_LOWERCASE", false)
+	viper.SetDefault("PASSWORD_REQUIRE_DIGIT", false)
+	viper.SetDefault("PASSWORD_REQUIRE_SYMBOL", false)
+	viper.SetDefault("PASSWORD_REJECT_PERSONAL_INFO", true)
+	viper.SetDefault("PASSWORD_BREACHED_CORPUS", "")
+	viper.SetDefault("PASSWORD_HISTORY_SIZE", 0)
+	viper.SetDefault("PASSWORD_MAX_AGE_DAYS", 0)
 	viper.SetDefault("PLD_BASE_URL", "http://98.81.235.22")
 	viper.SetDefault("PLD_TIMEOUT", 10)
 	viper.SetDefault("RABBITMQ_HOST", "localhost")
 	viper.SetDefault("RABBITMQ_PORT", "5672")
 	viper.SetDefault("RABBITMQ_USER", "guest")
 	viper.SetDefault("RABBITMQ_PASSWORD", "guest")
+	viper.SetDefault("RABBITMQ_PUBLISH_BUFFER", 1000)
+	viper.SetDefault("RABBITMQ_PREFETCH", 10)
+	viper.SetDefault("RABBITMQ_CONSUMER_WORKERS", 4)
+	viper.SetDefault("RABBITMQ_MAX_ATTEMPTS", 5)
+	viper.SetDefault("RABBITMQ_RETRY_BASE_DELAY", 5)
+	viper.SetDefault("RABBITMQ_RETRY_MAX_DELAY", 300)
+	viper.SetDefault("RABBITMQ_RECONNECT_DELAY", 1)
+	viper.SetDefault("RABBITMQ_MAX_RECONNECT_DELAY", 30)
+	viper.SetDefault("OUTBOX_POLL_INTERVAL", 1)
+	viper.SetDefault("OUTBOX_BATCH_SIZE", 100)
+	viper.SetDefault("OUTBOX_MAX_BACKOFF", 300)
+	viper.SetDefault("OUTBOX_STUCK_AFTER", 300)
+	viper.SetDefault("OUTBOX_RETENTION", 168)
 
 	if err := viper.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
@@ -85,8 +200,10 @@ func Load() (*Config, error) {
 
 	config := &Config{
 		Server: ServerConfig{
-			Port: viper.GetString("SERVER_PORT"),
-			Host: viper.GetString("SERVER_HOST"),
+			Port:           viper.GetString("SERVER_PORT"),
+			Host:           viper.GetString("SERVER_HOST"),
+			MetricsAddr:    viper.GetString("METRICS_ADDR"),
+			TrustedProxies: splitList(viper.GetString("TRUSTED_PROXIES")),
 		},
 		Database: DatabaseConfig{
 			Host:     viper.GetString("DB_HOST"),
@@ -97,18 +214,73 @@ func Load() (*Config, error) {
 			SSLMode:  viper.GetString("DB_SSLMODE"),
 		},
 		JWT: JWTConfig{
-			SecretKey: jwtSecret,
-			ExpiresIn: viper.GetInt("JWT_EXPIRES_IN"),
+			SecretKey:          jwtSecret,
+			ExpiresIn:          accessTokenExpiresIn(),
+			RefreshExpiresIn:   viper.GetInt("JWT_REFRESH_EXPIRES_IN"),
+			RevocationCacheTTL: viper.GetInt("JWT_REVOCATION_CACHE_TTL"),
+			KeysDir:            viper.GetString("JWT_KEYS_DIR"),
+			ActiveKeyID:        viper.GetString("JWT_ACTIVE_KEY_ID"),
+			Issuer:             viper.GetString("JWT_ISSUER"),
+			Audience:           viper.GetString("JWT_AUDIENCE"),
+			JWKSMaxAge:         viper.GetInt("JWT_JWKS_MAX_AGE"),
+		},
+		Auth: AuthConfig{
+			PasswordResetTTL:         viper.GetInt("PASSWORD_RESET_TTL"),
+			EmailVerificationTTL:     viper.GetInt("EMAIL_VERIFICATION_TTL"),
+			RequireEmailVerification: viper.GetBool("REQUIRE_EMAIL_VERIFICATION"),
+			LoginMaxFailures:         viper.GetInt("LOGIN_MAX_FAILURES"),
+			LoginIPMaxFailures:       viper.GetInt("LOGIN_IP_MAX_FAILURES"),
+			LoginLockoutDuration:     viper.GetInt("LOGIN_LOCKOUT_DURATION"),
+			LoginBackoffBase:         viper.GetInt("LOGIN_BACKOFF_BASE"),
+			LoginBackoffMax:          viper.GetInt("LOGIN_BACKOFF_MAX"),
+			SignupConcealExisting:    viper.GetBool("SIGNUP_CONCEAL_EXISTING"),
+			SignupConcealMinDuration: viper.GetInt("SIGNUP_CONCEAL_MIN_DURATION"),
+			MFAEncryptionKey:         viper.GetString("MFA_ENCRYPTION_KEY"),
+			MFAIssuer:                viper.GetString("MFA_ISSUER"),
+		},
+		PasswordHash: PasswordHashConfig{
+			Algorithm:         viper.GetString("PASSWORD_HASH_ALGORITHM"),
+			Argon2Memory:      viper.GetInt("ARGON2_MEMORY"),
+			Argon2Iterations:  viper.GetInt("ARGON2_ITERATIONS"),
+			Argon2Parallelism: viper.GetInt("ARGON2_PARALLELISM"),
+			BcryptCost:        viper.GetInt("BCRYPT_COST"),
+		},
+		PasswordPolicy: PasswordPolicyConfig{
+			MinLength:          viper.GetInt("PASSWORD_MIN_LENGTH"),
+			MaxLength:          viper.GetInt("PASSWORD_MAX_LENGTH"),
+			RequireUppercase:   viper.GetBool("PASSWORD_REQUIRE_UPPERCASE"),
+			RequireLowercase:   viper.GetBool("PASSWORD_REQUIRE_LOWERCASE"),
+			RequireDigit:       viper.GetBool("PASSWORD_REQUIRE_DIGIT"),
+			RequireSymbol:      viper.GetBool("PASSWORD_REQUIRE_SYMBOL"),
+			RejectPersonalInfo: viper.GetBool("PASSWORD_REJECT_PERSONAL_INFO"),
+			BreachedCorpus:     viper.GetString("PASSWORD_BREACHED_CORPUS"),
+			HistorySize:        viper.GetInt("PASSWORD_HISTORY_SIZE"),
+			MaxAgeDays:         viper.GetInt("PASSWORD_MAX_AGE_DAYS"),
 		},
 		PLD: PLDConfig{
 			BaseURL: viper.GetString("PLD_BASE_URL"),
 			Timeout: viper.GetInt("PLD_TIMEOUT"),
 		},
 		RabbitMQ: RabbitMQConfig{
-			Host:     viper.GetString("RABBITMQ_HOST"),
-			Port:     viper.GetString("RABBITMQ_PORT"),
-			User:     viper.GetString("RABBITMQ_USER"),
-			Password: viper.GetString("RABBITMQ_PASSWORD"),
+			Host:              viper.GetString("RABBITMQ_HOST"),
+			Port:              viper.GetString("RABBITMQ_PORT"),
+			User:              viper.GetString("RABBITMQ_USER"),
+			Password:          viper.GetString("RABBITMQ_PASSWORD"),
+			PublishBuffer:     viper.GetInt("RABBITMQ_PUBLISH_BUFFER"),
+			Prefetch:          viper.GetInt("RABBITMQ_PREFETCH"),
+			ConsumerWorkers:   viper.GetInt("RABBITMQ_CONSUMER_WORKERS"),
+			MaxAttempts:       viper.GetInt("RABBITMQ_MAX_ATTEMPTS"),
+			RetryBaseDelay:    viper.GetInt("RABBITMQ_RETRY_BASE_DELAY"),
+			RetryMaxDelay:     viper.GetInt("RABBITMQ_RETRY_MAX_DELAY"),
+			ReconnectDelay:    viper.GetInt("RABBITMQ_RECONNECT_DELAY"),
+			MaxReconnectDelay: viper.GetInt("RABBITMQ_MAX_RECONNECT_DELAY"),
+		},
+		Outbox: OutboxConfig{
+			PollInterval: viper.GetInt("OUTBOX_POLL_INTERVAL"),
+			BatchSize:    viper.GetInt("OUTBOX_BATCH_SIZE"),
+			MaxBackoff:   viper.GetInt("OUTBOX_MAX_BACKOFF"),
+			StuckAfter:   viper.GetInt("OUTBOX_STUCK_AFTER"),
+			Retention:    viper.GetInt("OUTBOX_RETENTION"),
 		},
 	}
 
@@ -122,3 +294,28 @@ func Load() (*Config, error) {
 	return config, nil
 }
 
+// splitList separa una lista por comas; una lista vacía devuelve nil.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// accessTokenExpiresIn lee JWT_ACCESS_EXPIRES_IN en minutos. Si no está
+// definida usa JWT_EXPIRES_IN, su nombre anterior, que iba en horas, para que
+// los despliegues existentes conserven la duración configurada.
+func accessTokenExpiresIn() int {
+	if viper.IsSet("JWT_ACCESS_EXPIRES_IN") {
+		return viper.GetInt("JWT_ACCESS_EXPIRES_IN")
+	}
+	if viper.IsSet("JWT_EXPIRES_IN") {
+		log.Printf("JWT_EXPIRES_IN está obsoleta, use JWT_ACCESS_EXPIRES_IN (en minutos)")
+		return viper.GetInt("JWT_EXPIRES_IN") * 60
+	}
+	return 15
+}
+
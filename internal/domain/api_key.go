@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// APIKeyPrefix distingue las API keys de otros tokens, por ejemplo en
+	// escáneres de secretos.
+	APIKeyPrefix = "ak_"
+
+	apiKeyBytes = 32
+	// apiKeyDisplayLength es lo que se guarda en claro para que el usuario
+	// reconozca la key en el listado.
+	apiKeyDisplayLength = len(APIKeyPrefix) + 8
+)
+
+// APIKey es una credencial de larga duración para acceso de máquinas. Como con
+// los refresh tokens, solo se guarda su hash.
+type APIKey struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	Name       string    `gorm:"not null"`
+	Prefix     string    `gorm:"not null"`
+	KeyHash    string    `gorm:"uniqueIndex;not null"`
+	Scopes     string    // separados por espacios, como el claim scope
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+func (k *APIKey) ScopeList() []string {
+	return strings.Fields(k.Scopes)
+}
+
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && now.After(*k.ExpiresAt)
+}
+
+// NewAPIKey genera el valor en claro, que solo se muestra al crearla, y la
+// entidad a persistir con su hash.
+func NewAPIKey(userID uuid.UUID, name string, scopes []string, expiresAt *time.Time) (string, *APIKey, error) {
+	buf := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", nil, fmt.Errorf("error al generar API key: %w", err)
+	}
+	raw := APIKeyPrefix + base64.RawURLEncoding.EncodeToString(buf)
+
+	return raw, &APIKey{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    raw[:apiKeyDisplayLength],
+		KeyHash:   HashToken(raw),
+		Scopes:    strings.Join(scopes, " "),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidEvent indica que un evento no podrá procesarse nunca. El
+// consumidor aparca sin reintentar los mensajes cuyo handler lo devuelve.
+var ErrInvalidEvent = errors.New("evento inválido")
+
+// EventSchemaVersion es la versión del sobre y de los payloads que emite el
+// servicio. Los mensajes anteriores al sobre se leen como versión 0.
+const EventSchemaVersion = 1
+
+// EventEnvelope envuelve todos los eventos publicados. ID identifica el evento
+// para descartar duplicados y se conserva en los reintentos.
+type EventEnvelope struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	SchemaVersion int             `json:"schema_version"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	CorrelationID string          `json:"correlation_id,omitempty"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+type UserCreatedPayload struct {
+	UserID    string    `json:"user_id"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (p *UserCreatedPayload) Validate() error {
+	if p.UserID == "" || p.Email == "" || p.CreatedAt.IsZero() {
+		return errors.New("user_id, email y created_at son requeridos")
+	}
+	if _, err := uuid.Parse(p.UserID); err != nil {
+		return fmt.Errorf("user_id inválido: %w", err)
+	}
+	return nil
+}
+
+type UserUpdatedPayload struct {
+	UserID    string    `json:"user_id"`
+	Email     string    `json:"email"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type PasswordChangedPayload struct {
+	UserID    string    `json:"user_id"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+type UserLockedPayload struct {
+	UserID      string    `json:"user_id"`
+	Email       string    `json:"email"`
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+// NewEventPayload devuelve un payload vacío del tipo que corresponde al evento.
+func NewEventPayload(eventType string) (interface{}, error) {
+	switch eventType {
+	case EventUserCreated:
+		return &UserCreatedPayload{}, nil
+	case EventUserUpdated:
+		return &UserUpdatedPayload{}, nil
+	case EventPasswordChanged:
+		return &PasswordChangedPayload{}, nil
+	case EventUserLocked:
+		return &UserLockedPayload{}, nil
+	default:
+		return nil, fmt.Errorf("tipo de evento desconocido: %s", eventType)
+	}
+}
+
+// NewEventEnvelope toma el correlation ID de ctx.
+func NewEventEnvelope(ctx context.Context, eventType string, payload interface{}) (*EventEnvelope, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("error al serializar evento %s: %w", eventType, err)
+	}
+
+	return &EventEnvelope{
+		ID:            uuid.NewString(),
+		Type:          eventType,
+		SchemaVersion: EventSchemaVersion,
+		OccurredAt:    time.Now().UTC(),
+		CorrelationID: CorrelationIDFromContext(ctx),
+		Payload:       body,
+	}, nil
+}
+
+func EncodeEvent(ctx context.Context, eventType string, payload interface{}) ([]byte, error) {
+	envelope, err := NewEventEnvelope(ctx, eventType, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, fmt.Errorf("error al serializar evento %s: %w", eventType, err)
+	}
+	return body, nil
+}
+
+// DecodeEvent lee un evento publicado. Los mensajes anteriores al sobre son el
+// payload sin envolver: se devuelven con defaultType, versión 0 y sin ID.
+func DecodeEvent(body []byte, defaultType string) (*EventEnvelope, error) {
+	var envelope EventEnvelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, fmt.Errorf("error al parsear evento: %w", err)
+	}
+
+	// "payload": null llega como el literal null, no como nil
+	if envelope.Type == "" || len(envelope.Payload) == 0 || bytes.Equal(envelope.Payload, []byte("null")) {
+		return &EventEnvelope{
+			Type:    defaultType,
+			Payload: json.RawMessage(body),
+		}, nil
+	}
+
+	if envelope.SchemaVersion > EventSchemaVersion {
+		return nil, fmt.Errorf("versión de esquema %d no soportada para %s", envelope.SchemaVersion, envelope.Type)
+	}
+
+	return &envelope, nil
+}
+
+func (e *EventEnvelope) DecodePayload(payload interface{}) error {
+	if err := json.Unmarshal(e.Payload, payload); err != nil {
+		return fmt.Errorf("error al parsear payload de %s: %w", e.Type, err)
+	}
+	return nil
+}
+
+type correlationIDKey struct{}
+
+// WithCorrelationID guarda en ctx el ID que relaciona una petición con los
+// eventos que origina.
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, correlationID)
+}
+
+func CorrelationIDFromContext(ctx context.Context) string {
+	correlationID, _ := ctx.Value(correlationIDKey{}).(string)
+	return correlationID
+}
+
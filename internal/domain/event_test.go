@@ -0,0 +1,143 @@
+package domain_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+func TestEncodeEvent_RoundTrip(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		eventType string
+		payload   interface{}
+	}{
+		{domain.EventUserCreated, &domain.UserCreatedPayload{UserID: "u-1", Email: "ana@example.com", CreatedAt: now}},
+		{domain.EventUserUpdated, &domain.UserUpdatedPayload{UserID: "u-1", Email: "ana@example.com", UpdatedAt: now}},
+		{domain.EventPasswordChanged, &domain.PasswordChangedPayload{UserID: "u-1", ChangedAt: now}},
+		{domain.EventUserLocked, &domain.UserLockedPayload{UserID: "u-1", Email: "ana@example.com", LockedUntil: now}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.eventType, func(t *testing.T) {
+			// Arrange
+			ctx := domain.WithCorrelationID(context.Background(), "req-123")
+
+			// Act
+			body, err := domain.EncodeEvent(ctx, tt.eventType, tt.payload)
+			if err != nil {
+				t.Fatalf("Expected no error encoding, got %v", err)
+			}
+			event, err := domain.DecodeEvent(body, "")
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error decoding, got %v", err)
+			}
+
+			if event.ID == "" || event.Type != tt.eventType || event.SchemaVersion != domain.EventSchemaVersion {
+				t.Errorf("Unexpected envelope %+v", event)
+			}
+
+			if event.CorrelationID != "req-123" {
+				t.Errorf("Expected correlation ID req-123, got %q", event.CorrelationID)
+			}
+
+			decoded, err := domain.NewEventPayload(event.Type)
+			if err != nil {
+				t.Fatalf("Expected a payload type, got %v", err)
+			}
+			if err := event.DecodePayload(decoded); err != nil {
+				t.Fatalf("Expected no error decoding payload, got %v", err)
+			}
+
+			if !reflect.DeepEqual(decoded, tt.payload) {
+				t.Errorf("Expected payload %+v, got %+v", tt.payload, decoded)
+			}
+		})
+	}
+}
+
+func TestDecodeEvent_LegacyMessage(t *testing.T) {
+	// Arrange
+	body := []byte(`{"user_id":"7f1c2d1e-5b0a-4c43-9d2e-3b8f1a6c9e10","email":"ana@example.com","created_at":"2026-01-02T03:04:05Z"}`)
+
+	// Act
+	event, err := domain.DecodeEvent(body, domain.EventUserCreated)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if event.Type != domain.EventUserCreated || event.SchemaVersion != 0 || event.ID != "" {
+		t.Errorf("Unexpected envelope %+v", event)
+	}
+
+	var payload domain.UserCreatedPayload
+	if err := event.DecodePayload(&payload); err != nil {
+		t.Fatalf("Expected no error decoding payload, got %v", err)
+	}
+
+	if err := payload.Validate(); err != nil {
+		t.Errorf("Expected a valid payload, got %v", err)
+	}
+}
+
+func TestDecodeEvent_NullPayload(t *testing.T) {
+	encoded, err := domain.EncodeEvent(context.Background(), domain.EventUserCreated, nil)
+	if err != nil {
+		t.Fatalf("Expected no error encoding, got %v", err)
+	}
+
+	tests := []struct {
+		name string
+		body []byte
+	}{
+		{"literal null", []byte(`{"id":"e-1","type":"user.created","schema_version":1,"payload":null}`)},
+		{"encoded nil payload", encoded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			event, err := domain.DecodeEvent(tt.body, domain.EventUserCreated)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if event.SchemaVersion != 0 || event.ID != "" || string(event.Payload) != string(tt.body) {
+				t.Errorf("Expected a legacy envelope, got %+v", event)
+			}
+
+			var payload domain.UserCreatedPayload
+			if err := event.DecodePayload(&payload); err != nil {
+				t.Fatalf("Expected no error decoding payload, got %v", err)
+			}
+
+			if err := payload.Validate(); err == nil {
+				t.Error("Expected the payload to be rejected")
+			}
+		})
+	}
+}
+
+func TestDecodeEvent_UnsupportedSchemaVersion(t *testing.T) {
+	// Arrange
+	body := []byte(`{"id":"e-1","type":"user.created","schema_version":99,"payload":{}}`)
+
+	// Act
+	_, err := domain.DecodeEvent(body, domain.EventUserCreated)
+
+	// Assert
+	if err == nil {
+		t.Error("Expected an error for an unsupported schema version")
+	}
+}
+
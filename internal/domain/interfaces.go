@@ -2,12 +2,24 @@ package domain
 
 import (
 	"context"
+	"time"
 )
 
 type UserRepository interface {
 	Create(ctx context.Context, user *User) error
+	// CreateWithOutbox inserta el usuario y el mensaje en una sola transacción.
+	CreateWithOutbox(ctx context.Context, user *User, message *OutboxMessage) error
 	FindByEmail(ctx context.Context, email string) (*User, error)
 	FindByID(ctx context.Context, id string) (*User, error)
+	List(ctx context.Context, filter UserFilter) ([]*User, int64, error)
+	// Update escribe solo las columnas indicadas para no pisar cambios
+	// concurrentes en el resto (estado, rol) con una copia desactualizada.
+	Update(ctx context.Context, user *User, columns ...string) error
+	// Delete elimina el usuario junto con sus sesiones, refresh tokens, API keys,
+	// historial de contraseñas, códigos de recuperación, tokens de un solo uso y
+	// contadores de intentos, en una sola transacción. Las revocaciones de access
+	// tokens se conservan para que los ya emitidos sigan rechazándose.
+	Delete(ctx context.Context, id string) error
 }
 
 type PLDService interface {
@@ -15,19 +27,163 @@ type PLDService interface {
 }
 
 type EventPublisher interface {
+	MessagePublisher
 	PublishUserCreated(ctx context.Context, userID, email string, createdAt int64) error
+	PublishUserUpdated(ctx context.Context, userID, email string, updatedAt int64) error
+	PublishPasswordChanged(ctx context.Context, userID string, changedAt int64) error
+	PublishUserLocked(ctx context.Context, userID, email string, lockedUntil int64) error
+	// Close espera, como mucho hasta que venza ctx, a que se confirmen las
+	// publicaciones pendientes.
+	Close(ctx context.Context) error
+}
+
+// MessagePublisher publica un evento ya serializado; lo usa el relay del outbox.
+type MessagePublisher interface {
+	Publish(ctx context.Context, eventType string, body []byte) error
+}
+
+// OutboxRepository gestiona los mensajes pendientes del outbox. ClaimPending
+// aplaza los mensajes que devuelve hasta now+lease para que otra instancia no
+// los publique a la vez; si el relay muere, vuelven a estar disponibles al
+// vencer el plazo.
+type OutboxRepository interface {
+	ClaimPending(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*OutboxMessage, error)
+	MarkSent(ctx context.Context, id string, sentAt time.Time) error
+	MarkFailed(ctx context.Context, id string, lastError string, nextAttemptAt time.Time) error
+	Stats(ctx context.Context, stuckBefore time.Time) (*OutboxStats, error)
+	DeleteSentBefore(ctx context.Context, before time.Time) (int64, error)
 }
 
 type EventConsumer interface {
-	ConsumeUserCreated(ctx context.Context, handler func(userID, email string, createdAt int64) error) error
+	ConsumeUserCreated(ctx context.Context, handler func(ctx context.Context, event *EventEnvelope, payload *UserCreatedPayload) error) error
+	Close() error
 }
 
 type JWTService interface {
-	GenerateToken(userID string) (string, error)
-	ValidateToken(tokenString string) (string, error)
+	GenerateToken(principal Principal) (string, error)
+	ValidateToken(tokenString string) (*Principal, error)
+	GenerateRefreshToken() (string, time.Time, error)
+	PublicKeys() []JSONWebKey
+	SigningAlgorithm() string
+}
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *RefreshToken) error
+	FindByHash(ctx context.Context, tokenHash string) (*RefreshToken, error)
+	MarkUsed(ctx context.Context, id string) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeAllForUser(ctx context.Context, userID string) error
+	RevokeOtherFamilies(ctx context.Context, userID, keepFamilyID string) error
+}
+
+// TokenRevocationStore invalida access tokens antes de que expiren: uno
+// concreto, todos los de un usuario o todos los de una sesión.
+type TokenRevocationStore interface {
+	RevokeToken(ctx context.Context, principal *Principal) error
+	RevokeAllForUser(ctx context.Context, userID string, revokedBefore time.Time) error
+	RevokeSession(ctx context.Context, sessionID string) error
+	IsRevoked(ctx context.Context, principal *Principal) (bool, error)
+}
+
+// SessionRepository guarda las sesiones abiertas. La revocación la hace
+// TokenRevocationStore.RevokeSession.
+type SessionRepository interface {
+	Create(ctx context.Context, session *Session) error
+	FindByID(ctx context.Context, id string) (*Session, error)
+	// ListActive devuelve las sesiones del usuario sin revocar y con un
+	// refresh token vigente, de la más a la menos reciente.
+	ListActive(ctx context.Context, userID string) ([]*Session, error)
+	Touch(ctx context.Context, id, ipAddress string, seenAt time.Time) error
 }
 
 type UserEventRepository interface {
 	Create(ctx context.Context, event *UserEvent) error
 }
 
+type OneTimeTokenRepository interface {
+	Create(ctx context.Context, token *OneTimeToken) error
+	FindByHash(ctx context.Context, purpose, tokenHash string) (*OneTimeToken, error)
+	MarkUsed(ctx context.Context, id string) (bool, error)
+	InvalidateForUser(ctx context.Context, userID, purpose string) error
+}
+
+// LoginAttemptRepository guarda los contadores de logins fallidos. Find
+// devuelve un contador vacío si la clave no tiene fallos registrados.
+// RecordFailure incrementa el contador de forma atómica y devuelve el valor
+// resultante; Release deshace un incremento.
+type LoginAttemptRepository interface {
+	Find(ctx context.Context, key string) (*LoginAttempt, error)
+	RecordFailure(ctx context.Context, key string) (*LoginAttempt, error)
+	Release(ctx context.Context, key string) error
+	Lock(ctx context.Context, key string, until time.Time) error
+	Reset(ctx context.Context, key string) error
+}
+
+type RecoveryCodeRepository interface {
+	ReplaceForUser(ctx context.Context, userID string, codes []*RecoveryCode) error
+	Consume(ctx context.Context, userID, codeHash string) (bool, error)
+}
+
+// PasswordHistoryRepository guarda los hashes de contraseñas anteriores.
+type PasswordHistoryRepository interface {
+	Add(ctx context.Context, entry *PasswordHistoryEntry) error
+	// ListRecent devuelve las últimas entradas del usuario, de la más reciente
+	// a la más antigua.
+	ListRecent(ctx context.Context, userID string, limit int) ([]*PasswordHistoryEntry, error)
+	// Prune conserva solo las keep entradas más recientes del usuario.
+	Prune(ctx context.Context, userID string, keep int) error
+}
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *APIKey) error
+	FindByHash(ctx context.Context, keyHash string) (*APIKey, error)
+	FindByID(ctx context.Context, id string) (*APIKey, error)
+	// ListByUser devuelve las keys no revocadas del usuario, incluidas las
+	// expiradas, de la más a la menos reciente.
+	ListByUser(ctx context.Context, userID string) ([]*APIKey, error)
+	Revoke(ctx context.Context, id string) error
+	MarkUsed(ctx context.Context, id string, usedAt time.Time) error
+}
+
+type OAuthClientRepository interface {
+	Create(ctx context.Context, client *OAuthClient) error
+	FindByID(ctx context.Context, id string) (*OAuthClient, error)
+	List(ctx context.Context) ([]*OAuthClient, error)
+	Disable(ctx context.Context, id string) error
+}
+
+// APIKeyAuthenticator resuelve el principal de una API key presentada en
+// Authorization: ApiKey <key>.
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (*Principal, error)
+}
+
+// SecretCipher cifra secretos que deben poder recuperarse, como los de TOTP.
+type SecretCipher interface {
+	Encrypt(plaintext string) (string, error)
+	Decrypt(ciphertext string) (string, error)
+}
+
+// PasswordHasher calcula y verifica hashes de contraseña autodescriptivos
+// (formato PHC o crypt de bcrypt): cada hash indica su algoritmo y coste.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(password, encodedHash string) (bool, error)
+	// NeedsRehash indica si el hash usa otro algoritmo o parámetros distintos
+	// de los configurados.
+	NeedsRehash(encodedHash string) bool
+}
+
+// BreachedPasswordChecker indica si una contraseña aparece en filtraciones
+// conocidas.
+type BreachedPasswordChecker interface {
+	IsBreached(ctx context.Context, password string) (bool, error)
+}
+
+// Notifier entrega mensajes al usuario fuera de banda.
+type Notifier interface {
+	SendPasswordReset(ctx context.Context, email, token string, expiresAt time.Time) error
+	SendEmailVerification(ctx context.Context, email, token string, expiresAt time.Time) error
+	SendAccountExists(ctx context.Context, email string) error
+}
+
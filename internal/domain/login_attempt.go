@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// LoginAttempt cuenta los logins fallidos de una clave (un email o una IP).
+// Las claves de email se registran exista o no la cuenta, para que el bloqueo
+// no revele qué emails están registrados.
+type LoginAttempt struct {
+	Key           string `gorm:"primaryKey"`
+	Failures      int    `gorm:"not null;default:0"`
+	LastFailureAt time.Time
+	LockedUntil   *time.Time
+}
+
+func (LoginAttempt) TableName() string {
+	return "login_attempts"
+}
+
+func AccountAttemptKey(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func IPAttemptKey(ip string) string {
+	return "ip:" + ip
+}
+
+// LockoutPolicy define el retraso exponencial entre intentos fallidos y el
+// bloqueo temporal tras MaxFailures fallos.
+type LockoutPolicy struct {
+	MaxFailures     int
+	LockoutDuration time.Duration
+	BaseDelay       time.Duration
+	MaxDelay        time.Duration
+}
+
+// Delay devuelve la espera exigida tras el número de fallos indicado:
+// BaseDelay, 2*BaseDelay, 4*BaseDelay... hasta MaxDelay.
+func (p LockoutPolicy) Delay(failures int) time.Duration {
+	if failures <= 0 || p.BaseDelay <= 0 {
+		return 0
+	}
+	delay := p.BaseDelay
+	for i := 1; i < failures; i++ {
+		delay *= 2
+		if delay >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+	return delay
+}
+
+// RetryAt devuelve el momento a partir del cual se admite otro intento.
+func (a *LoginAttempt) RetryAt(policy LockoutPolicy) time.Time {
+	if a.LockedUntil != nil {
+		return *a.LockedUntil
+	}
+	if a.Failures == 0 {
+		return time.Time{}
+	}
+	return a.LastFailureAt.Add(policy.Delay(a.Failures))
+}
+
+// IsStale indica que el contador debe reiniciarse: el bloqueo ya expiró o no
+// hubo fallos durante LockoutDuration.
+func (a *LoginAttempt) IsStale(now time.Time, policy LockoutPolicy) bool {
+	if a.LockedUntil != nil {
+		return now.After(*a.LockedUntil)
+	}
+	return a.Failures > 0 && now.Sub(a.LastFailureAt) > policy.LockoutDuration
+}
+
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const oauthClientSecretBytes = 32
+
+// OAuthClient es un servicio registrado que obtiene tokens con el grant
+// client_credentials. Su client_id es el ID; del secreto solo se guarda el hash.
+type OAuthClient struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Name       string    `gorm:"not null"`
+	SecretHash string    `gorm:"not null"`
+	Scopes     string    // scopes que puede pedir, separados por espacios
+	DisabledAt *time.Time
+	CreatedAt  time.Time
+}
+
+func (OAuthClient) TableName() string {
+	return "oauth_clients"
+}
+
+func (c *OAuthClient) ScopeList() []string {
+	return strings.Fields(c.Scopes)
+}
+
+func (c *OAuthClient) IsDisabled() bool {
+	return c.DisabledAt != nil
+}
+
+// NewOAuthClient genera el secreto en claro, que solo se muestra al registrar
+// el cliente, y la entidad a persistir con su hash.
+func NewOAuthClient(name string, scopes []string) (string, *OAuthClient, error) {
+	buf := make([]byte, oauthClientSecretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", nil, fmt.Errorf("error al generar secreto de cliente: %w", err)
+	}
+	secret := base64.RawURLEncoding.EncodeToString(buf)
+
+	return secret, &OAuthClient{
+		Name:       name,
+		SecretHash: HashToken(secret),
+		Scopes:     strings.Join(scopes, " "),
+	}, nil
+}
+
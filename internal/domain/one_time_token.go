@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	TokenPurposePasswordReset     = "password_reset"
+	TokenPurposeEmailVerification = "email_verification"
+	TokenPurposeMFAChallenge      = "mfa_challenge"
+)
+
+const oneTimeTokenBytes = 32
+
+// OneTimeToken es un token opaco de un solo uso enviado al usuario fuera de
+// banda (por ejemplo, por email). Como con los refresh tokens, solo se guarda
+// su hash.
+type OneTimeToken struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	Purpose   string    `gorm:"not null;index"`
+	TokenHash string    `gorm:"uniqueIndex;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+func (OneTimeToken) TableName() string {
+	return "one_time_tokens"
+}
+
+func (t *OneTimeToken) IsExpired(now time.Time) bool {
+	return now.After(t.ExpiresAt)
+}
+
+// NewOneTimeToken genera el valor en claro que se entrega al usuario y la
+// entidad a persistir con su hash.
+func NewOneTimeToken(userID uuid.UUID, purpose string, ttl time.Duration) (string, *OneTimeToken, error) {
+	buf := make([]byte, oneTimeTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", nil, fmt.Errorf("error al generar token: %w", err)
+	}
+	raw := base64.RawURLEncoding.EncodeToString(buf)
+
+	return raw, &OneTimeToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: HashToken(raw),
+		ExpiresAt: time.Now().Add(ttl),
+	}, nil
+}
+
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxMessage es un evento pendiente de publicar. Se escribe en la misma
+// transacción que el cambio que lo origina y el relay lo publica después, de
+// modo que el evento no se pierde si RabbitMQ no está disponible o el proceso
+// termina.
+type OutboxMessage struct {
+	ID            uuid.UUID       `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	EventType     string          `gorm:"not null"`
+	Payload       json.RawMessage `gorm:"type:jsonb;not null"` // EventEnvelope serializado
+	Attempts      int             `gorm:"not null;default:0"`
+	LastError     string
+	NextAttemptAt time.Time  `gorm:"not null;index"` // también bloquea el mensaje mientras un relay lo publica
+	SentAt        *time.Time `gorm:"index"`
+	CreatedAt     time.Time
+}
+
+func (OutboxMessage) TableName() string {
+	return "outbox"
+}
+
+func (m *OutboxMessage) IsSent() bool {
+	return m.SentAt != nil
+}
+
+// OutboxStats resume los mensajes pendientes; Stuck son los que llevan más
+// tiempo del tolerado sin publicarse.
+type OutboxStats struct {
+	Pending       int64
+	Stuck         int64
+	OldestPending *time.Time
+}
+
+// NewOutboxMessage envuelve el payload en el momento de escribirlo, así el ID
+// del evento se mantiene en todos los intentos de publicación.
+func NewOutboxMessage(ctx context.Context, eventType string, payload interface{}) (*OutboxMessage, error) {
+	body, err := EncodeEvent(ctx, eventType, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutboxMessage{
+		EventType:     eventType,
+		Payload:       body,
+		NextAttemptAt: time.Now(),
+	}, nil
+}
+
+// NewUserCreatedMessage requiere el ID y CreatedAt definitivos del usuario. La
+// fecha se trunca a segundos, como en el resto de eventos.
+func NewUserCreatedMessage(ctx context.Context, user *User) (*OutboxMessage, error) {
+	return NewOutboxMessage(ctx, EventUserCreated, UserCreatedPayload{
+		UserID:    user.ID.String(),
+		Email:     user.Email,
+		CreatedAt: user.CreatedAt.UTC().Truncate(time.Second),
+	})
+}
+
@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	MinPasswordLength = 8
+	// MaxPasswordLength es el límite de bcrypt, que ignora los bytes siguientes.
+	// Argon2id no lo tiene, pero se mantiene mientras bcrypt sea configurable.
+	MaxPasswordLength = 72
+)
+
+// Reglas de la política de contraseñas, usadas como identificador en los
+// detalles del error.
+const (
+	PasswordRuleMinLength    = "min_length"
+	PasswordRuleMaxLength    = "max_length"
+	PasswordRuleUppercase    = "uppercase"
+	PasswordRuleLowercase    = "lowercase"
+	PasswordRuleDigit        = "digit"
+	PasswordRuleSymbol       = "symbol"
+	PasswordRulePersonalInfo = "personal_info"
+	PasswordRuleBreached     = "breached"
+	PasswordRuleReused       = "reused"
+)
+
+// personalInfoMinLength evita rechazar contraseñas por fragmentos muy cortos
+// del email o del nombre.
+const personalInfoMinLength = 3
+
+// PasswordPolicy define las reglas que debe cumplir una contraseña nueva.
+// MaxLength se mide en bytes.
+type PasswordPolicy struct {
+	MinLength          int
+	MaxLength          int
+	RequireUppercase   bool
+	RequireLowercase   bool
+	RequireDigit       bool
+	RequireSymbol      bool
+	RejectPersonalInfo bool
+}
+
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:          MinPasswordLength,
+		MaxLength:          MaxPasswordLength,
+		RejectPersonalInfo: true,
+	}
+}
+
+type PasswordViolation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// PasswordPolicyError agrupa todas las reglas incumplidas.
+type PasswordPolicyError struct {
+	Violations []PasswordViolation
+}
+
+func (e *PasswordPolicyError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		messages[i] = violation.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Check devuelve las reglas que incumple password, o nil si las cumple todas.
+// email y name pueden ir vacíos.
+func (p PasswordPolicy) Check(password, email, name string) []PasswordViolation {
+	var violations []PasswordViolation
+	add := func(rule, message string) {
+		violations = append(violations, PasswordViolation{Rule: rule, Message: message})
+	}
+
+	if len([]rune(password)) < p.MinLength {
+		add(PasswordRuleMinLength, fmt.Sprintf("la contraseña debe tener al menos %d caracteres", p.MinLength))
+	}
+	if p.MaxLength > 0 && len(password) > p.MaxLength {
+		add(PasswordRuleMaxLength, fmt.Sprintf("la contraseña no puede exceder %d bytes", p.MaxLength))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	if p.RequireUppercase && !hasUpper {
+		add(PasswordRuleUppercase, "la contraseña debe incluir una mayúscula")
+	}
+	if p.RequireLowercase && !hasLower {
+		add(PasswordRuleLowercase, "la contraseña debe incluir una minúscula")
+	}
+	if p.RequireDigit && !hasDigit {
+		add(PasswordRuleDigit, "la contraseña debe incluir un número")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		add(PasswordRuleSymbol, "la contraseña debe incluir un símbolo")
+	}
+
+	if p.RejectPersonalInfo && containsPersonalInfo(password, email, name) {
+		add(PasswordRulePersonalInfo, "la contraseña no puede contener el email ni el nombre")
+	}
+
+	return violations
+}
+
+// containsPersonalInfo compara sin distinguir mayúsculas contra la parte local
+// del email y cada palabra del nombre.
+func containsPersonalInfo(password, email, name string) bool {
+	lowered := strings.ToLower(password)
+
+	fragments := strings.Fields(strings.ToLower(name))
+	local, _, _ := strings.Cut(strings.ToLower(email), "@")
+	fragments = append(fragments, local)
+
+	for _, fragment := range fragments {
+		if len([]rune(fragment)) >= personalInfoMinLength && strings.Contains(lowered, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
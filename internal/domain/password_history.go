@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordHistoryEntry guarda el hash de una contraseña que tuvo el usuario,
+// para impedir que la reutilice.
+type PasswordHistoryEntry struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID       uuid.UUID `gorm:"type:uuid;not null;index"`
+	PasswordHash string    `gorm:"not null"`
+	CreatedAt    time.Time `gorm:"not null;index"`
+}
+
+func (PasswordHistoryEntry) TableName() string {
+	return "password_history"
+}
+
+// PasswordRotationPolicy limita la reutilización y la vigencia de las
+// contraseñas. Los valores cero desactivan cada regla.
+type PasswordRotationPolicy struct {
+	HistorySize int           // últimas contraseñas, incluida la actual, que no pueden repetirse
+	MaxAge      time.Duration // vigencia de una contraseña antes de exigir el cambio
+}
+
+func (p PasswordRotationPolicy) IsExpired(user *User, now time.Time) bool {
+	if p.MaxAge <= 0 {
+		return false
+	}
+	return now.After(user.PasswordSetAt().Add(p.MaxAge))
+}
+
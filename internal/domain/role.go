@@ -0,0 +1,71 @@
+package domain
+
+const (
+	RoleUser    = "user"
+	RoleSupport = "support"
+	RoleAdmin   = "admin"
+)
+
+const (
+	PermissionUsersRead   = "users:read"
+	PermissionUsersWrite  = "users:write"
+	PermissionUsersDelete = "users:delete"
+
+	PermissionClientsRead  = "clients:read"
+	PermissionClientsWrite = "clients:write"
+)
+
+// ScopePasswordChange marca un token restringido: no lleva roles y solo se
+// acepta en el cambio de contraseña.
+const ScopePasswordChange = "password:change"
+
+// rolePermissions define qué permisos otorga cada rol. Los permisos de un rol
+// también se emiten como scopes en el access token.
+var rolePermissions = map[string][]string{
+	RoleUser:    {},
+	RoleSupport: {PermissionUsersRead},
+	RoleAdmin:   {PermissionUsersRead, PermissionUsersWrite, PermissionUsersDelete, PermissionClientsRead, PermissionClientsWrite},
+}
+
+// IsValidPermission indica si permission lo otorga algún rol; son los únicos
+// scopes que se pueden conceder a un cliente OAuth2.
+func IsValidPermission(permission string) bool {
+	for _, permissions := range rolePermissions {
+		for _, p := range permissions {
+			if p == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func IsValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+func RoleHasPermission(role, permission string) bool {
+	for _, p := range rolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// PermissionsForRoles devuelve la unión, sin duplicados, de los permisos de los roles.
+func PermissionsForRoles(roles []string) []string {
+	seen := make(map[string]bool)
+	permissions := []string{}
+	for _, role := range roles {
+		for _, p := range rolePermissions[role] {
+			if !seen[p] {
+				seen[p] = true
+				permissions = append(permissions, p)
+			}
+		}
+	}
+	return permissions
+}
+
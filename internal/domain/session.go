@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session es un dispositivo o cliente con sesión abierta. Su ID es el FamilyID
+// de los refresh tokens que emite y viaja como sid en los access tokens.
+type Session struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	UserAgent  string
+	IPAddress  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time `gorm:"not null"`
+	RevokedAt  *time.Time
+}
+
+func (Session) TableName() string {
+	return "sessions"
+}
+
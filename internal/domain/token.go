@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TokenTimePrecision es la precisión de iat en los access tokens. Con
+// segundos, una revocación no podría distinguir los tokens emitidos antes y
+// después de ella en el mismo segundo.
+const TokenTimePrecision = time.Millisecond
+
+// RevocationCutoff devuelve el límite de una revocación hecha en revokedAt: se
+// rechazan los tokens con iat hasta ese límite. Cubre los milisegundos
+// anteriores a revokedAt, pero no el suyo, para que el token que se emite justo
+// después (al cambiar la contraseña o reactivar la cuenta) siga siendo válido.
+// Un token emitido antes en ese mismo milisegundo sobrevive.
+func RevocationCutoff(revokedAt time.Time) time.Time {
+	return revokedAt.Truncate(TokenTimePrecision).Add(-time.Nanosecond)
+}
+
+// Principal identifica a quien hace la petición. Al emitir un token se indican
+// UserID, SessionID, Roles y Scopes; al validarlo se completan TokenID, IssuedAt
+// y ExpiresAt. Las peticiones con API key solo llevan UserID, Scopes y APIKeyID.
+// Los tokens de un cliente OAuth2 (client_credentials) llevan ClientID y
+// Scopes, sin UserID.
+type Principal struct {
+	UserID    string
+	SessionID string
+	Roles     []string
+	Scopes    []string
+	TokenID   string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+	APIKeyID  string
+	ClientID  string
+}
+
+// IsRestricted es verdadero para los tokens emitidos con la contraseña
+// expirada, que solo sirven para cambiarla.
+func (p *Principal) IsRestricted() bool {
+	return p.HasScope(ScopePasswordChange)
+}
+
+// IsAPIKey es verdadero si la petición se autenticó con una API key y no con
+// un access token.
+func (p *Principal) IsAPIKey() bool {
+	return p.APIKeyID != ""
+}
+
+// IsClient es verdadero para los tokens emitidos a un servicio, que no actúa
+// en nombre de ningún usuario.
+func (p *Principal) IsClient() bool {
+	return p.ClientID != ""
+}
+
+func (p *Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Principal) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPermission es verdadero si el permiso viene como scope en el token o lo
+// otorga alguno de sus roles.
+func (p *Principal) HasPermission(permission string) bool {
+	if p.HasScope(permission) {
+		return true
+	}
+	for _, role := range p.Roles {
+		if RoleHasPermission(role, permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// RevokedToken registra un access token (por jti) invalidado antes de expirar.
+type RevokedToken struct {
+	TokenID   string    `gorm:"primary_key"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	CreatedAt time.Time
+}
+
+func (RevokedToken) TableName() string {
+	return "revoked_tokens"
+}
+
+// UserTokenRevocation invalida todos los access tokens de un usuario emitidos
+// hasta RevokedBefore (logout de todas las sesiones).
+type UserTokenRevocation struct {
+	UserID        uuid.UUID `gorm:"type:uuid;primary_key"`
+	RevokedBefore time.Time `gorm:"not null"`
+	UpdatedAt     time.Time
+}
+
+func (UserTokenRevocation) TableName() string {
+	return "user_token_revocations"
+}
+
+// JSONWebKey es la representación pública (RFC 7517) de una clave de verificación.
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
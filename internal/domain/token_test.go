@@ -0,0 +1,39 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+)
+
+func TestRevocationCutoff(t *testing.T) {
+	// Arrange
+	revokedAt := time.Date(2026, 1, 2, 3, 4, 5, 678_900_000, time.UTC)
+
+	tests := []struct {
+		name     string
+		issuedAt time.Time
+		revoked  bool
+	}{
+		{"earlier in the same second", time.Date(2026, 1, 2, 3, 4, 5, 100_000_000, time.UTC), true},
+		{"previous millisecond", time.Date(2026, 1, 2, 3, 4, 5, 677_000_000, time.UTC), true},
+		{"second-precision token from the same second", time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), true},
+		// Hueco aceptado: iat no distingue dentro del milisegundo de la revocación
+		{"same millisecond", time.Date(2026, 1, 2, 3, 4, 5, 678_000_000, time.UTC), false},
+		{"next millisecond", time.Date(2026, 1, 2, 3, 4, 5, 679_000_000, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			cutoff := domain.RevocationCutoff(revokedAt)
+
+			// Assert
+			if revoked := !tt.issuedAt.After(cutoff); revoked != tt.revoked {
+				t.Errorf("Expected revoked=%v for iat %v, got %v", tt.revoked, tt.issuedAt, revoked)
+			}
+		})
+	}
+}
+
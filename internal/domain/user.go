@@ -5,34 +5,69 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	UserStatusActive    = "active"
+	UserStatusSuspended = "suspended"
 )
 
 type User struct {
-	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	Email     string    `gorm:"uniqueIndex;not null"`
-	Password  string    `gorm:"not null"` // Hash bcrypt
-	Name      string    `gorm:"not null"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID                uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Email             string    `gorm:"uniqueIndex;not null"`
+	Password          string    `gorm:"not null"` // Hash autodescriptivo de PasswordHasher
+	Name              string    `gorm:"not null"`
+	Role              string    `gorm:"not null;default:user"`
+	Status            string    `gorm:"not null;default:active;index"`
+	EmailVerifiedAt   *time.Time
+	PasswordChangedAt *time.Time // nil en usuarios anteriores al historial; se toma CreatedAt
+	MFAEnabled        bool       `gorm:"not null;default:false"`
+	TOTPSecret        string     // cifrado con SecretCipher; vacío si no hay enrolamiento
+	TOTPLastStep      int64      // último paso TOTP aceptado, para impedir reutilizar un código
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
 }
 
 func (User) TableName() string {
 	return "users"
 }
 
-func (u *User) HashPassword(password string) error {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+func (u *User) HashPassword(hasher PasswordHasher, password string) error {
+	hashedPassword, err := hasher.Hash(password)
 	if err != nil {
 		return err
 	}
-	u.Password = string(hashedPassword)
+	u.Password = hashedPassword
 	return nil
 }
 
-func (u *User) VerifyPassword(password string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password))
-	return err == nil
+// VerifyPassword trata un hash ilegible como contraseña incorrecta.
+func (u *User) VerifyPassword(hasher PasswordHasher, password string) bool {
+	ok, err := hasher.Verify(password, u.Password)
+	return err == nil && ok
+}
+
+// PasswordSetAt devuelve cuándo se eligió la contraseña actual.
+func (u *User) PasswordSetAt() time.Time {
+	if u.PasswordChangedAt != nil {
+		return *u.PasswordChangedAt
+	}
+	return u.CreatedAt
+}
+
+func (u *User) IsSuspended() bool {
+	return u.Status == UserStatusSuspended
+}
+
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+func (u *User) Roles() []string {
+	if u.Role == "" {
+		return []string{RoleUser}
+	}
+	return []string{u.Role}
 }
 
 func (u *User) Validate() error {
@@ -48,6 +83,23 @@ func (u *User) Validate() error {
 	if len(u.Email) < 5 {
 		return fmt.Errorf("email inválido")
 	}
+	if u.Role != "" && !IsValidRole(u.Role) {
+		return fmt.Errorf("rol inválido")
+	}
+	if u.Status != "" && u.Status != UserStatusActive && u.Status != UserStatusSuspended {
+		return fmt.Errorf("estado inválido")
+	}
 	return nil
 }
 
+// UserFilter filtra y pagina el listado de usuarios. Los campos vacíos no filtran.
+type UserFilter struct {
+	Email       string
+	Name        string
+	Status      string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	Page        int
+	PageSize    int
+}
+
@@ -7,6 +7,13 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	EventUserCreated     = "user.created"
+	EventUserUpdated     = "user.updated"
+	EventPasswordChanged = "user.password_changed"
+	EventUserLocked      = "user.locked"
+)
+
 type UserEvent struct {
 	ID        uuid.UUID       `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
 	UserID    uuid.UUID       `gorm:"type:uuid;not null;index"`
@@ -19,9 +26,3 @@ func (UserEvent) TableName() string {
 	return "user_events"
 }
 
-type EventPayload struct {
-	UserID    string    `json:"user_id"`
-	Email     string    `json:"email"`
-	CreatedAt time.Time `json:"created_at"`
-}
-
@@ -0,0 +1,87 @@
+package breach
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const prefixLength = 5
+
+// Corpus agrupa los SHA-1 por su prefijo de 5 caracteres, igual que la API de
+// rangos de Have I Been Pwned, para poder cambiar a esa API sin tocar el
+// contrato.
+type Corpus struct {
+	suffixes map[string]map[string]struct{}
+	size     int
+}
+
+// LoadCorpus lee un archivo con un SHA-1 en hexadecimal por línea, con el
+// formato HASH o HASH:CONTEO de las descargas de Have I Been Pwned. Las líneas
+// vacías y las que empiezan con # se ignoran.
+func LoadCorpus(path string) (*Corpus, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error al abrir corpus de contraseñas filtradas: %w", err)
+	}
+	defer file.Close()
+
+	c := &Corpus{suffixes: make(map[string]map[string]struct{})}
+
+	scanner := bufio.NewScanner(file)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		hash, _, _ := strings.Cut(text, ":")
+		hash = strings.ToUpper(hash)
+		if len(hash) != sha1.Size*2 {
+			return nil, fmt.Errorf("hash inválido en la línea %d del corpus", line)
+		}
+		if _, err := hex.DecodeString(hash); err != nil {
+			return nil, fmt.Errorf("hash inválido en la línea %d del corpus: %w", line, err)
+		}
+
+		c.add(hash)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error al leer corpus de contraseñas filtradas: %w", err)
+	}
+
+	return c, nil
+}
+
+func (c *Corpus) add(hash string) {
+	prefix, suffix := hash[:prefixLength], hash[prefixLength:]
+	bucket, ok := c.suffixes[prefix]
+	if !ok {
+		bucket = make(map[string]struct{})
+		c.suffixes[prefix] = bucket
+	}
+	if _, exists := bucket[suffix]; !exists {
+		bucket[suffix] = struct{}{}
+		c.size++
+	}
+}
+
+func (c *Corpus) IsBreached(ctx context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	_, found := c.suffixes[hash[:prefixLength]][hash[prefixLength:]]
+	return found, nil
+}
+
+// Size devuelve la cantidad de hashes distintos cargados.
+func (c *Corpus) Size() int {
+	return c.size
+}
+
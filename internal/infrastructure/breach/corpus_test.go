@@ -0,0 +1,83 @@
+package breach_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"user-service/internal/infrastructure/breach"
+)
+
+func writeCorpus(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Expected no error writing corpus, got %v", err)
+	}
+	return path
+}
+
+func TestLoadCorpus_IsBreached(t *testing.T) {
+	// Arrange
+	// SHA-1 de "password" con conteo, y de "123456" en minúsculas sin conteo
+	path := writeCorpus(t, `# corpus de prueba
+5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:9545824
+
+7c4a8d09ca3762af61e59520943dc26494f8941b
+`)
+
+	corpus, err := breach.LoadCorpus(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tests := []struct {
+		password string
+		breached bool
+	}{
+		{password: "password", breached: true},
+		{password: "123456", breached: true},
+		{password: "Password", breached: false},
+		{password: "correct horse battery staple", breached: false},
+	}
+
+	// Act & Assert
+	for _, tt := range tests {
+		breached, err := corpus.IsBreached(context.Background(), tt.password)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if breached != tt.breached {
+			t.Errorf("Expected IsBreached(%q) = %v, got %v", tt.password, tt.breached, breached)
+		}
+	}
+
+	if corpus.Size() != 2 {
+		t.Errorf("Expected 2 hashes, got %d", corpus.Size())
+	}
+}
+
+func TestLoadCorpus_InvalidLine(t *testing.T) {
+	// Arrange
+	path := writeCorpus(t, "5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8\nno-es-un-hash\n")
+
+	// Act
+	_, err := breach.LoadCorpus(path)
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected error for invalid line, got nil")
+	}
+}
+
+func TestLoadCorpus_MissingFile(t *testing.T) {
+	// Act
+	_, err := breach.LoadCorpus(filepath.Join(t.TempDir(), "missing.txt"))
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected error for missing file, got nil")
+	}
+}
+
@@ -0,0 +1,68 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+
+	"user-service/internal/domain"
+)
+
+type aesGCMCipher struct {
+	aead cipher.AEAD
+}
+
+// NewAESGCMCipher cifra con AES-256-GCM. La clave se deriva con SHA-256 del
+// valor configurado, así que cambiarlo invalida los secretos ya guardados.
+func NewAESGCMCipher(key string) (domain.SecretCipher, error) {
+	if key == "" {
+		return nil, fmt.Errorf("la clave de cifrado es requerida")
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, fmt.Errorf("error al crear cifrador: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("error al crear cifrador: %w", err)
+	}
+
+	return &aesGCMCipher{aead: aead}, nil
+}
+
+// Encrypt devuelve nonce || ciphertext en base64.
+func (c *aesGCMCipher) Encrypt(plaintext string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("error al generar nonce: %w", err)
+	}
+
+	sealed := c.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (c *aesGCMCipher) Decrypt(ciphertext string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("error al decodificar secreto: %w", err)
+	}
+
+	nonceSize := c.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return "", fmt.Errorf("secreto cifrado inválido")
+	}
+
+	plaintext, err := c.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+	if err != nil {
+		return "", fmt.Errorf("error al descifrar secreto: %w", err)
+	}
+
+	return string(plaintext), nil
+}
+
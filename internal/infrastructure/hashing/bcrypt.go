@@ -0,0 +1,42 @@
+package hashing
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type bcryptHasher struct {
+	cost int
+}
+
+func (h *bcryptHasher) Hash(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", fmt.Errorf("error al calcular hash bcrypt: %w", err)
+	}
+	return string(hashed), nil
+}
+
+func (h *bcryptHasher) Verify(password, encodedHash string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(encodedHash), []byte(password))
+	if err == bcrypt.ErrMismatchedHashAndPassword {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("hash bcrypt inválido: %w", err)
+	}
+	return true, nil
+}
+
+func (h *bcryptHasher) NeedsRehash(encodedHash string) bool {
+	cost, err := bcrypt.Cost([]byte(encodedHash))
+	return err != nil || cost != h.cost
+}
+
+// isBcryptHash reconoce las variantes $2a$, $2b$ y $2y$.
+func isBcryptHash(encodedHash string) bool {
+	return len(encodedHash) > 4 && strings.HasPrefix(encodedHash, "$2") && encodedHash[3] == '$'
+}
+
@@ -0,0 +1,88 @@
+package hashing
+
+import (
+	"fmt"
+	"strings"
+
+	"user-service/internal/domain"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+)
+
+// Config elige el algoritmo de los hashes nuevos y los costes de cada uno.
+type Config struct {
+	Algorithm  string
+	Argon2id   Argon2idParams
+	BcryptCost int
+}
+
+type passwordHasher struct {
+	algorithm string
+	argon2id  *argon2idHasher
+	bcrypt    *bcryptHasher
+}
+
+// NewPasswordHasher calcula los hashes nuevos con el algoritmo configurado y
+// verifica los de cualquier algoritmo soportado, según el prefijo del hash.
+// Los hashes de otro algoritmo o con otros costes se reportan en NeedsRehash.
+func NewPasswordHasher(cfg Config) (domain.PasswordHasher, error) {
+	if cfg.Algorithm != AlgorithmArgon2id && cfg.Algorithm != AlgorithmBcrypt {
+		return nil, fmt.Errorf("algoritmo de hash no soportado: %s", cfg.Algorithm)
+	}
+
+	params := cfg.Argon2id
+	if params.SaltLength == 0 {
+		params.SaltLength = DefaultArgon2idParams.SaltLength
+	}
+	if params.KeyLength == 0 {
+		params.KeyLength = DefaultArgon2idParams.KeyLength
+	}
+	if params.Memory == 0 || params.Iterations == 0 || params.Parallelism == 0 {
+		return nil, fmt.Errorf("parámetros argon2id inválidos")
+	}
+
+	if cfg.BcryptCost < bcrypt.MinCost || cfg.BcryptCost > bcrypt.MaxCost {
+		return nil, fmt.Errorf("coste bcrypt inválido: %d", cfg.BcryptCost)
+	}
+
+	return &passwordHasher{
+		algorithm: cfg.Algorithm,
+		argon2id:  &argon2idHasher{params: params},
+		bcrypt:    &bcryptHasher{cost: cfg.BcryptCost},
+	}, nil
+}
+
+func (h *passwordHasher) Hash(password string) (string, error) {
+	if h.algorithm == AlgorithmBcrypt {
+		return h.bcrypt.Hash(password)
+	}
+	return h.argon2id.Hash(password)
+}
+
+func (h *passwordHasher) Verify(password, encodedHash string) (bool, error) {
+	switch {
+	case strings.HasPrefix(encodedHash, argon2idPrefix):
+		return h.argon2id.Verify(password, encodedHash)
+	case isBcryptHash(encodedHash):
+		return h.bcrypt.Verify(password, encodedHash)
+	default:
+		return false, fmt.Errorf("formato de hash desconocido")
+	}
+}
+
+func (h *passwordHasher) NeedsRehash(encodedHash string) bool {
+	switch {
+	case strings.HasPrefix(encodedHash, argon2idPrefix):
+		return h.algorithm != AlgorithmArgon2id || h.argon2id.NeedsRehash(encodedHash)
+	case isBcryptHash(encodedHash):
+		return h.algorithm != AlgorithmBcrypt || h.bcrypt.NeedsRehash(encodedHash)
+	default:
+		return true
+	}
+}
+
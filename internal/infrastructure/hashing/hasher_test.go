@@ -0,0 +1,147 @@
+package hashing_test
+
+import (
+	"strings"
+	"testing"
+
+	"user-service/internal/domain"
+	"user-service/internal/infrastructure/hashing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testArgon2idParams mantiene los tests rápidos; no usar en producción.
+var testArgon2idParams = hashing.Argon2idParams{Memory: 1024, Iterations: 1, Parallelism: 1}
+
+func newHasher(t *testing.T, algorithm string, params hashing.Argon2idParams, bcryptCost int) domain.PasswordHasher {
+	t.Helper()
+	hasher, err := hashing.NewPasswordHasher(hashing.Config{
+		Algorithm:  algorithm,
+		Argon2id:   params,
+		BcryptCost: bcryptCost,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return hasher
+}
+
+func TestPasswordHasher_Argon2idRoundTrip(t *testing.T) {
+	// Arrange
+	hasher := newHasher(t, hashing.AlgorithmArgon2id, testArgon2idParams, bcrypt.MinCost)
+
+	// Act
+	encoded, err := hasher.Hash("password123")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !strings.HasPrefix(encoded, "$argon2id$v=19$m=1024,t=1,p=1$") {
+		t.Errorf("Expected PHC argon2id hash, got %s", encoded)
+	}
+
+	if ok, err := hasher.Verify("password123", encoded); err != nil || !ok {
+		t.Errorf("Expected password to verify, got %v, %v", ok, err)
+	}
+
+	if ok, _ := hasher.Verify("wrongpassword", encoded); ok {
+		t.Error("Expected wrong password to fail")
+	}
+
+	if hasher.NeedsRehash(encoded) {
+		t.Error("Expected fresh hash not to need rehash")
+	}
+}
+
+func TestPasswordHasher_VerifiesLegacyBcrypt(t *testing.T) {
+	// Arrange
+	hasher := newHasher(t, hashing.AlgorithmArgon2id, testArgon2idParams, bcrypt.MinCost)
+	legacy, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+
+	// Act
+	ok, err := hasher.Verify("password123", string(legacy))
+
+	// Assert
+	if err != nil || !ok {
+		t.Fatalf("Expected bcrypt hash to verify, got %v, %v", ok, err)
+	}
+
+	if !hasher.NeedsRehash(string(legacy)) {
+		t.Error("Expected bcrypt hash to need rehash when argon2id is configured")
+	}
+}
+
+func TestPasswordHasher_NeedsRehashOnParamChange(t *testing.T) {
+	// Arrange
+	old := newHasher(t, hashing.AlgorithmArgon2id, testArgon2idParams, bcrypt.MinCost)
+	stronger := testArgon2idParams
+	stronger.Iterations = 2
+	current := newHasher(t, hashing.AlgorithmArgon2id, stronger, bcrypt.MinCost)
+
+	encoded, _ := old.Hash("password123")
+
+	// Act
+	needsRehash := current.NeedsRehash(encoded)
+
+	// Assert
+	if !needsRehash {
+		t.Error("Expected hash with old parameters to need rehash")
+	}
+
+	if ok, err := current.Verify("password123", encoded); err != nil || !ok {
+		t.Errorf("Expected hash with old parameters to still verify, got %v, %v", ok, err)
+	}
+}
+
+func TestPasswordHasher_BcryptConfigured(t *testing.T) {
+	// Arrange
+	hasher := newHasher(t, hashing.AlgorithmBcrypt, testArgon2idParams, bcrypt.MinCost)
+	argonHasher := newHasher(t, hashing.AlgorithmArgon2id, testArgon2idParams, bcrypt.MinCost)
+	argonHash, _ := argonHasher.Hash("password123")
+
+	// Act
+	encoded, err := hasher.Hash("password123")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if cost, err := bcrypt.Cost([]byte(encoded)); err != nil || cost != bcrypt.MinCost {
+		t.Errorf("Expected bcrypt hash with cost %d, got %d (%v)", bcrypt.MinCost, cost, err)
+	}
+
+	if !hasher.NeedsRehash(argonHash) {
+		t.Error("Expected argon2id hash to need rehash when bcrypt is configured")
+	}
+}
+
+func TestPasswordHasher_RejectsUnknownFormat(t *testing.T) {
+	// Arrange
+	hasher := newHasher(t, hashing.AlgorithmArgon2id, testArgon2idParams, bcrypt.MinCost)
+
+	// Act
+	ok, err := hasher.Verify("password123", "password123")
+
+	// Assert
+	if ok || err == nil {
+		t.Errorf("Expected error for unknown hash format, got %v, %v", ok, err)
+	}
+}
+
+func TestNewPasswordHasher_InvalidConfig(t *testing.T) {
+	tests := []hashing.Config{
+		{Algorithm: "md5", Argon2id: testArgon2idParams, BcryptCost: bcrypt.MinCost},
+		{Algorithm: hashing.AlgorithmArgon2id, Argon2id: hashing.Argon2idParams{}, BcryptCost: bcrypt.MinCost},
+		{Algorithm: hashing.AlgorithmBcrypt, Argon2id: testArgon2idParams, BcryptCost: 99},
+	}
+
+	for _, cfg := range tests {
+		if _, err := hashing.NewPasswordHasher(cfg); err == nil {
+			t.Errorf("Expected error for config %+v", cfg)
+		}
+	}
+}
+
@@ -0,0 +1,46 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+
+	domain "user-service/internal/domain"
+)
+
+// JWK devuelve la clave pública en formato JWK. Las claves HMAC no se publican.
+func (k *SigningKey) JWK() (domain.JSONWebKey, bool) {
+	jwk := domain.JSONWebKey{
+		Use: "sig",
+		Kid: k.ID,
+		Alg: k.Method.Alg(),
+	}
+
+	switch key := k.PublicKey().(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = encodeBase64URL(key.N.Bytes())
+		jwk.E = encodeBase64URL(big.NewInt(int64(key.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+		jwk.Kty = "EC"
+		jwk.Crv = key.Curve.Params().Name
+		jwk.X = encodeBase64URL(key.X.FillBytes(make([]byte, size)))
+		jwk.Y = encodeBase64URL(key.Y.FillBytes(make([]byte, size)))
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = encodeBase64URL(key)
+	default:
+		return domain.JSONWebKey{}, false
+	}
+
+	return jwk, true
+}
+
+func encodeBase64URL(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
@@ -0,0 +1,233 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// SigningKey es una clave identificada por kid. Las claves de solo verificación
+// (claves retiradas o de las que solo se tiene la pública) no tienen signKey.
+type SigningKey struct {
+	ID        string
+	Method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+func (k *SigningKey) CanSign() bool {
+	return k.signKey != nil
+}
+
+// PublicKey devuelve la clave pública para claves asimétricas y nil para HMAC.
+func (k *SigningKey) PublicKey() crypto.PublicKey {
+	if _, ok := k.verifyKey.([]byte); ok {
+		return nil
+	}
+	return k.verifyKey
+}
+
+func NewHMACKey(id string, secret []byte) *SigningKey {
+	return &SigningKey{
+		ID:        id,
+		Method:    jwt.SigningMethodHS256,
+		signKey:   secret,
+		verifyKey: secret,
+	}
+}
+
+// newHMACVerificationKey construye una clave HS256 que solo verifica.
+func newHMACVerificationKey(id string, secret []byte) *SigningKey {
+	return &SigningKey{
+		ID:        id,
+		Method:    jwt.SigningMethodHS256,
+		verifyKey: secret,
+	}
+}
+
+// NewSigningKey construye una clave a partir de una clave privada RSA, ECDSA
+// P-256 o Ed25519; el algoritmo (RS256, ES256 o EdDSA) se deduce del tipo.
+func NewSigningKey(id string, privateKey crypto.Signer) (*SigningKey, error) {
+	method, err := methodForPublicKey(privateKey.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &SigningKey{
+		ID:        id,
+		Method:    method,
+		signKey:   privateKey,
+		verifyKey: privateKey.Public(),
+	}, nil
+}
+
+// NewVerificationKey construye una clave de solo verificación.
+func NewVerificationKey(id string, publicKey crypto.PublicKey) (*SigningKey, error) {
+	method, err := methodForPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &SigningKey{
+		ID:        id,
+		Method:    method,
+		verifyKey: publicKey,
+	}, nil
+}
+
+func methodForPublicKey(publicKey crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return jwt.SigningMethodRS256, nil
+	case *ecdsa.PublicKey:
+		if key.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("curva ECDSA no soportada: %s", key.Curve.Params().Name)
+		}
+		return jwt.SigningMethodES256, nil
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, fmt.Errorf("tipo de clave no soportado: %T", publicKey)
+	}
+}
+
+// Keyring contiene una clave activa con la que se firma y todas las claves
+// (incluida la activa) con las que se aceptan tokens.
+type Keyring struct {
+	active *SigningKey
+	keys   map[string]*SigningKey
+}
+
+func NewKeyring(active *SigningKey, verificationKeys ...*SigningKey) (*Keyring, error) {
+	if active == nil || !active.CanSign() {
+		return nil, fmt.Errorf("la clave activa debe poder firmar")
+	}
+
+	keys := map[string]*SigningKey{active.ID: active}
+	for _, key := range verificationKeys {
+		if _, exists := keys[key.ID]; exists {
+			return nil, fmt.Errorf("kid duplicado: %s", key.ID)
+		}
+		keys[key.ID] = key
+	}
+
+	return &Keyring{active: active, keys: keys}, nil
+}
+
+func (k *Keyring) Active() *SigningKey {
+	return k.active
+}
+
+func (k *Keyring) Key(id string) (*SigningKey, bool) {
+	key, ok := k.keys[id]
+	return key, ok
+}
+
+// Keys devuelve todas las claves ordenadas por kid.
+func (k *Keyring) Keys() []*SigningKey {
+	keys := make([]*SigningKey, 0, len(k.keys))
+	for _, key := range k.keys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+	return keys
+}
+
+// LoadKeyring carga todas las claves PEM (*.pem) de dir usando el nombre del
+// archivo sin extensión como kid. La clave activeKeyID debe ser privada; el resto
+// pueden ser privadas o públicas y solo se usan para verificar. Rotar consiste en
+// añadir la clave nueva, cambiar activeKeyID y retirar la anterior cuando hayan
+// expirado los tokens que firmó.
+//
+// Si legacySecret no está vacío se añade como clave HS256 de solo verificación
+// (kid "hs256"), para que los tokens firmados con JWT_SECRET_KEY antes de pasar
+// a claves asimétricas sigan siendo válidos hasta que expiren.
+func LoadKeyring(dir, activeKeyID string, legacySecret []byte) (*Keyring, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, fmt.Errorf("error al listar claves: %w", err)
+	}
+
+	var active *SigningKey
+	var others []*SigningKey
+	for _, path := range paths {
+		id := strings.TrimSuffix(filepath.Base(path), ".pem")
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error al leer clave %s: %w", id, err)
+		}
+
+		key, err := ParseKeyPEM(id, data)
+		if err != nil {
+			return nil, fmt.Errorf("error al cargar clave %s: %w", id, err)
+		}
+
+		if id == activeKeyID {
+			active = key
+		} else {
+			others = append(others, key)
+		}
+	}
+
+	if active == nil {
+		return nil, fmt.Errorf("no se encontró la clave activa %s en %s", activeKeyID, dir)
+	}
+
+	if len(legacySecret) > 0 {
+		others = append(others, newHMACVerificationKey(hmacKeyID, legacySecret))
+	}
+
+	return NewKeyring(active, others...)
+}
+
+// ParseKeyPEM interpreta una clave privada (PKCS#8, PKCS#1 o SEC 1) o pública (PKIX).
+func ParseKeyPEM(id string, data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("PEM inválido")
+	}
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error al parsear clave pública: %w", err)
+		}
+		return NewVerificationKey(id, publicKey)
+	case "PRIVATE KEY":
+		privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error al parsear clave privada: %w", err)
+		}
+		signer, ok := privateKey.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("tipo de clave no soportado: %T", privateKey)
+		}
+		return NewSigningKey(id, signer)
+	case "RSA PRIVATE KEY":
+		privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error al parsear clave RSA: %w", err)
+		}
+		return NewSigningKey(id, privateKey)
+	case "EC PRIVATE KEY":
+		privateKey, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error al parsear clave EC: %w", err)
+		}
+		return NewSigningKey(id, privateKey)
+	default:
+		return nil, fmt.Errorf("tipo de bloque PEM no soportado: %s", block.Type)
+	}
+}
+
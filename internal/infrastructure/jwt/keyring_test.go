@@ -0,0 +1,287 @@
+package jwt_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"user-service/internal/domain"
+	"user-service/internal/infrastructure/jwt"
+)
+
+func generateKeys(t *testing.T) map[string]crypto.Signer {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Error generating RSA key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Error generating EC key: %v", err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Error generating Ed25519 key: %v", err)
+	}
+
+	return map[string]crypto.Signer{
+		"RS256": rsaKey,
+		"ES256": ecKey,
+		"EdDSA": edKey,
+	}
+}
+
+func TestKeyring_AsymmetricAlgorithms(t *testing.T) {
+	for alg, privateKey := range generateKeys(t) {
+		t.Run(alg, func(t *testing.T) {
+			// Arrange
+			key, err := jwt.NewSigningKey("key-1", privateKey)
+			if err != nil {
+				t.Fatalf("Error creating signing key: %v", err)
+			}
+			if key.Method.Alg() != alg {
+				t.Fatalf("Expected alg %s, got %s", alg, key.Method.Alg())
+			}
+
+			keyring, err := jwt.NewKeyring(key)
+			if err != nil {
+				t.Fatalf("Error creating keyring: %v", err)
+			}
+			service := jwt.NewJWTServiceWithKeyring(keyring, testIssuer, testAudience, 15, 720)
+
+			// Act
+			token, err := service.GenerateToken(domain.Principal{UserID: "test-user-id"})
+			if err != nil {
+				t.Fatalf("Error generating token: %v", err)
+			}
+			claims, err := service.ValidateToken(token)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if claims.UserID != "test-user-id" {
+				t.Errorf("Expected user ID test-user-id, got %s", claims.UserID)
+			}
+		})
+	}
+}
+
+func TestKeyring_RotationKeepsRetiredKeyValid(t *testing.T) {
+	// Arrange
+	keys := generateKeys(t)
+	oldKey, _ := jwt.NewSigningKey("2025-01", keys["RS256"])
+	newKey, _ := jwt.NewSigningKey("2025-02", keys["ES256"])
+
+	oldKeyring, _ := jwt.NewKeyring(oldKey)
+	oldService := jwt.NewJWTServiceWithKeyring(oldKeyring, testIssuer, testAudience, 15, 720)
+
+	oldToken, err := oldService.GenerateToken(domain.Principal{UserID: "test-user-id"})
+	if err != nil {
+		t.Fatalf("Error generating token: %v", err)
+	}
+
+	// La clave retirada se conserva solo con su parte pública
+	retired, _ := jwt.NewVerificationKey("2025-01", keys["RS256"].Public())
+	rotatedKeyring, err := jwt.NewKeyring(newKey, retired)
+	if err != nil {
+		t.Fatalf("Error creating keyring: %v", err)
+	}
+	rotatedService := jwt.NewJWTServiceWithKeyring(rotatedKeyring, testIssuer, testAudience, 15, 720)
+
+	// Act
+	_, errOld := rotatedService.ValidateToken(oldToken)
+	newToken, _ := rotatedService.GenerateToken(domain.Principal{UserID: "test-user-id"})
+	_, errNew := oldService.ValidateToken(newToken)
+
+	// Assert
+	if errOld != nil {
+		t.Errorf("Expected token signed by retired key to stay valid, got %v", errOld)
+	}
+	if errNew == nil {
+		t.Error("Expected token with unknown kid to be rejected")
+	}
+}
+
+func TestKeyring_RejectsVerificationOnlyActiveKey(t *testing.T) {
+	// Arrange
+	keys := generateKeys(t)
+	publicOnly, _ := jwt.NewVerificationKey("public", keys["EdDSA"].Public())
+
+	// Act
+	_, err := jwt.NewKeyring(publicOnly)
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected error for active key without private part, got nil")
+	}
+}
+
+func TestLoadKeyring_FromDirectory(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	keys := generateKeys(t)
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(keys["EdDSA"])
+	if err != nil {
+		t.Fatalf("Error marshaling private key: %v", err)
+	}
+	writePEM(t, filepath.Join(dir, "active.pem"), "PRIVATE KEY", privateDER)
+
+	publicDER, err := x509.MarshalPKIXPublicKey(keys["RS256"].Public())
+	if err != nil {
+		t.Fatalf("Error marshaling public key: %v", err)
+	}
+	writePEM(t, filepath.Join(dir, "retired.pem"), "PUBLIC KEY", publicDER)
+
+	// Act
+	keyring, err := jwt.LoadKeyring(dir, "active", nil)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if keyring.Active().ID != "active" || keyring.Active().Method.Alg() != "EdDSA" {
+		t.Errorf("Unexpected active key %s (%s)", keyring.Active().ID, keyring.Active().Method.Alg())
+	}
+	retired, ok := keyring.Key("retired")
+	if !ok || retired.CanSign() {
+		t.Error("Expected retired key to be loaded as verification-only")
+	}
+
+	if _, err := jwt.LoadKeyring(dir, "retired", nil); err == nil {
+		t.Error("Expected error when active key is public-only")
+	}
+}
+
+func TestLoadKeyring_LegacySecretKeepsHS256TokensValid(t *testing.T) {
+	// Arrange
+	secret := "test-secret-key-min-32-characters-long"
+	dir := t.TempDir()
+	keys := generateKeys(t)
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(keys["EdDSA"])
+	if err != nil {
+		t.Fatalf("Error marshaling private key: %v", err)
+	}
+	writePEM(t, filepath.Join(dir, "active.pem"), "PRIVATE KEY", privateDER)
+
+	hmacService, _ := jwt.NewJWTService(secret, testIssuer, testAudience, 15, 720)
+	hmacToken, err := hmacService.GenerateToken(domain.Principal{UserID: "test-user-id"})
+	if err != nil {
+		t.Fatalf("Error generating token: %v", err)
+	}
+
+	// Token anterior al keyring: HS256 sin kid
+	now := time.Now()
+	unsigned := gojwt.NewWithClaims(gojwt.SigningMethodHS256, jwt.Claims{
+		RegisteredClaims: gojwt.RegisteredClaims{
+			ID:        "legacy-token-id",
+			Subject:   "test-user-id",
+			Issuer:    testIssuer,
+			Audience:  gojwt.ClaimStrings{testAudience},
+			IssuedAt:  gojwt.NewNumericDate(now),
+			ExpiresAt: gojwt.NewNumericDate(now.Add(15 * time.Minute)),
+		},
+	})
+	kidlessToken, err := unsigned.SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("Error signing token: %v", err)
+	}
+
+	// Act
+	keyring, err := jwt.LoadKeyring(dir, "active", []byte(secret))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	service := jwt.NewJWTServiceWithKeyring(keyring, testIssuer, testAudience, 15, 720)
+
+	withoutSecret, _ := jwt.LoadKeyring(dir, "active", nil)
+	strict := jwt.NewJWTServiceWithKeyring(withoutSecret, testIssuer, testAudience, 15, 720)
+
+	// Assert
+	legacy, ok := keyring.Key("hs256")
+	if !ok || legacy.CanSign() {
+		t.Error("Expected HS256 secret to be loaded as verification-only")
+	}
+	if service.SigningAlgorithm() != "EdDSA" {
+		t.Errorf("Expected to sign with EdDSA, got %s", service.SigningAlgorithm())
+	}
+	if len(service.PublicKeys()) != 1 {
+		t.Errorf("Expected only the EdDSA key in JWKS, got %d keys", len(service.PublicKeys()))
+	}
+	for name, token := range map[string]string{"hs256 kid": hmacToken, "no kid": kidlessToken} {
+		if _, err := service.ValidateToken(token); err != nil {
+			t.Errorf("Expected %s token to stay valid, got %v", name, err)
+		}
+		if _, err := strict.ValidateToken(token); err == nil {
+			t.Errorf("Expected %s token to be rejected without legacy secret", name)
+		}
+	}
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("Error writing %s: %v", path, err)
+	}
+}
+
+func TestSigningKey_JWK(t *testing.T) {
+	keys := generateKeys(t)
+
+	tests := map[string]struct {
+		kty    string
+		crv    string
+		xBytes int
+	}{
+		"RS256": {kty: "RSA"},
+		"ES256": {kty: "EC", crv: "P-256", xBytes: 32},
+		"EdDSA": {kty: "OKP", crv: "Ed25519", xBytes: 32},
+	}
+
+	for alg, want := range tests {
+		t.Run(alg, func(t *testing.T) {
+			// Arrange
+			key, _ := jwt.NewSigningKey("key-"+alg, keys[alg])
+
+			// Act
+			jwk, ok := key.JWK()
+
+			// Assert
+			if !ok {
+				t.Fatal("Expected JWK for asymmetric key")
+			}
+			if jwk.Kty != want.kty || jwk.Crv != want.crv || jwk.Alg != alg || jwk.Kid != "key-"+alg {
+				t.Errorf("Unexpected JWK: %+v", jwk)
+			}
+			if want.xBytes > 0 {
+				x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+				if err != nil || len(x) != want.xBytes {
+					t.Errorf("Expected %d-byte x coordinate, got %q", want.xBytes, jwk.X)
+				}
+			}
+			if want.kty == "RSA" && (jwk.N == "" || jwk.E != "AQAB") {
+				t.Errorf("Unexpected RSA parameters: n=%q e=%q", jwk.N, jwk.E)
+			}
+		})
+	}
+
+	if _, ok := jwt.NewHMACKey("hs256", []byte("secret")).JWK(); ok {
+		t.Error("Expected HMAC key not to be exported as JWK")
+	}
+}
+
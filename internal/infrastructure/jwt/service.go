@@ -1,42 +1,97 @@
 package jwt
 
 import (
+	"crypto/rand"
+	"encoding/base64"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	domain "user-service/internal/domain"
 )
 
+const refreshTokenBytes = 32
+
+// hmacKeyID es el kid de la clave derivada de JWT_SECRET_KEY.
+const hmacKeyID = "hs256"
+
+func init() {
+	// iat, nbf y exp se emiten con milisegundos (ver domain.RevocationCutoff);
+	// el parser conserva microsegundos para que el redondeo recupere el milisegundo exacto.
+	jwt.TimePrecision = time.Microsecond
+}
+
 type jwtService struct {
-	secretKey string
-	expiresIn time.Duration
+	keyring          *Keyring
+	issuer           string
+	audience         string
+	expiresIn        time.Duration
+	refreshExpiresIn time.Duration
 }
 
+// Claims sigue RFC 9068: sub es el usuario, sid la sesión y scope una lista
+// separada por espacios. En los tokens de client_credentials sub y client_id
+// son el cliente.
 type Claims struct {
-	UserID string `json:"user_id"`
+	SessionID string   `json:"sid,omitempty"`
+	Roles     []string `json:"roles,omitempty"`
+	Scope     string   `json:"scope,omitempty"`
+	ClientID  string   `json:"client_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func NewJWTService(secretKey string, expiresInHours int) domain.JWTService {
+// NewJWTService firma con HS256 usando un secreto compartido.
+func NewJWTService(secretKey, issuer, audience string, expiresInMinutes, refreshExpiresInHours int) (domain.JWTService, error) {
+	keyring, err := NewKeyring(NewHMACKey(hmacKeyID, []byte(secretKey)))
+	if err != nil {
+		return nil, err
+	}
+	return NewJWTServiceWithKeyring(keyring, issuer, audience, expiresInMinutes, refreshExpiresInHours), nil
+}
+
+// NewJWTServiceWithKeyring firma con la clave activa del keyring y acepta tokens
+// firmados por cualquiera de sus claves. Si issuer o audience están vacíos no
+// se emiten ni se validan los claims iss o aud respectivamente.
+func NewJWTServiceWithKeyring(keyring *Keyring, issuer, audience string, expiresInMinutes, refreshExpiresInHours int) domain.JWTService {
 	return &jwtService{
-		secretKey: secretKey,
-		expiresIn: time.Duration(expiresInHours) * time.Hour,
+		keyring:          keyring,
+		issuer:           issuer,
+		audience:         audience,
+		expiresIn:        time.Duration(expiresInMinutes) * time.Minute,
+		refreshExpiresIn: time.Duration(refreshExpiresInHours) * time.Hour,
 	}
 }
 
-func (s *jwtService) GenerateToken(userID string) (string, error) {
+func (s *jwtService) GenerateToken(principal domain.Principal) (string, error) {
+	now := time.Now().Truncate(domain.TokenTimePrecision)
+	subject := principal.UserID
+	if principal.IsClient() {
+		subject = principal.ClientID
+	}
 	claims := &Claims{
-		UserID: userID,
+		SessionID: principal.SessionID,
+		Roles:     principal.Roles,
+		Scope:     strings.Join(principal.Scopes, " "),
+		ClientID:  principal.ClientID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.expiresIn)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
+			ID:        uuid.NewString(),
+			Subject:   subject,
+			Issuer:    s.issuer,
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.expiresIn)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
 		},
 	}
+	if s.audience != "" {
+		claims.Audience = jwt.ClaimStrings{s.audience}
+	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(s.secretKey))
+	active := s.keyring.Active()
+	token := jwt.NewWithClaims(active.Method, claims)
+	token.Header["kid"] = active.ID
+	tokenString, err := token.SignedString(active.signKey)
 	if err != nil {
 		return "", fmt.Errorf("error al firmar token: %w", err)
 	}
@@ -44,24 +99,99 @@ func (s *jwtService) GenerateToken(userID string) (string, error) {
 	return tokenString, nil
 }
 
-func (s *jwtService) ValidateToken(tokenString string) (string, error) {
+func (s *jwtService) ValidateToken(tokenString string) (*domain.Principal, error) {
 	claims := &Claims{}
 
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("método de firma inesperado: %v", token.Header["alg"])
-		}
-		return []byte(s.secretKey), nil
-	})
+	options := []jwt.ParserOption{jwt.WithIssuedAt()}
+	if s.issuer != "" {
+		options = append(options, jwt.WithIssuer(s.issuer))
+	}
+	if s.audience != "" {
+		options = append(options, jwt.WithAudience(s.audience))
+	}
+
+	token, err := jwt.ParseWithClaims(tokenString, claims, s.verificationKey, options...)
 
 	if err != nil {
-		return "", fmt.Errorf("error al parsear token: %w", err)
+		return nil, fmt.Errorf("error al parsear token: %w", err)
 	}
 
 	if !token.Valid {
-		return "", fmt.Errorf("token inválido")
+		return nil, fmt.Errorf("token inválido")
+	}
+
+	if claims.ID == "" || claims.Subject == "" || claims.IssuedAt == nil || claims.ExpiresAt == nil {
+		return nil, fmt.Errorf("token sin jti, sub, iat o exp")
+	}
+
+	principal := &domain.Principal{
+		UserID:    claims.Subject,
+		SessionID: claims.SessionID,
+		Roles:     claims.Roles,
+		Scopes:    strings.Fields(claims.Scope),
+		TokenID:   claims.ID,
+		// NumericDate es un float: se redondea para no perder el último milisegundo
+		IssuedAt:  claims.IssuedAt.Time.Round(domain.TokenTimePrecision),
+		ExpiresAt: claims.ExpiresAt.Time,
+		ClientID:  claims.ClientID,
+	}
+	if principal.IsClient() {
+		principal.UserID = ""
+	}
+
+	return principal, nil
+}
+
+// verificationKey elige la clave según el kid del header y exige que el alg del
+// token coincida con el de esa clave, para evitar confusiones de algoritmo.
+// Los tokens sin kid (emitidos antes de introducir el keyring) se firmaron con
+// JWT_SECRET_KEY, así que se verifican con la clave HS256 si el keyring la
+// conserva (ver LoadKeyring) y si no con la clave activa.
+func (s *jwtService) verificationKey(token *jwt.Token) (interface{}, error) {
+	key := s.keyring.Active()
+	if legacy, exists := s.keyring.Key(hmacKeyID); exists {
+		key = legacy
+	}
+	if kid, ok := token.Header["kid"].(string); ok {
+		found, exists := s.keyring.Key(kid)
+		if !exists {
+			return nil, fmt.Errorf("kid desconocido: %s", kid)
+		}
+		key = found
+	}
+
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("método de firma inesperado: %v", token.Header["alg"])
+	}
+
+	return key.verifyKey, nil
+}
+
+// PublicKeys devuelve todas las claves públicas del keyring, incluidas las que
+// aún no firman y las ya retiradas, para que los verificadores las conozcan
+// antes de la rotación y hasta que expiren sus tokens.
+func (s *jwtService) PublicKeys() []domain.JSONWebKey {
+	keys := []domain.JSONWebKey{}
+	for _, key := range s.keyring.Keys() {
+		if jwk, ok := key.JWK(); ok {
+			keys = append(keys, jwk)
+		}
+	}
+	return keys
+}
+
+func (s *jwtService) SigningAlgorithm() string {
+	return s.keyring.Active().Method.Alg()
+}
+
+// GenerateRefreshToken genera un token opaco aleatorio; no es un JWT y solo
+// tiene sentido contra el hash persistido en base de datos.
+func (s *jwtService) GenerateRefreshToken() (string, time.Time, error) {
+	buf := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", time.Time{}, fmt.Errorf("error al generar refresh token: %w", err)
 	}
 
-	return claims.UserID, nil
+	return base64.RawURLEncoding.EncodeToString(buf), time.Now().Add(s.refreshExpiresIn), nil
 }
 
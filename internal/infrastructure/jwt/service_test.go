@@ -1,22 +1,30 @@
 package jwt_test
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
 	"testing"
 	"time"
 
+	"user-service/internal/domain"
 	"user-service/internal/infrastructure/jwt"
 )
 
+const (
+	testIssuer   = "https://users.example.com"
+	testAudience = "user-service"
+)
+
 func TestJWTService_GenerateToken(t *testing.T) {
 	// Arrange
 	secretKey := "test-secret-key-min-32-characters-long"
-	expiresIn := 24 // horas
-	service := jwt.NewJWTService(secretKey, expiresIn)
+	expiresIn := 15 // minutos
+	service, _ := jwt.NewJWTService(secretKey, testIssuer, testAudience, expiresIn, 720)
 
 	userID := "test-user-id"
 
 	// Act
-	token, err := service.GenerateToken(userID)
+	token, err := service.GenerateToken(domain.Principal{UserID: userID})
 
 	// Assert
 	if err != nil {
@@ -31,33 +39,37 @@ func TestJWTService_GenerateToken(t *testing.T) {
 func TestJWTService_ValidateToken_Success(t *testing.T) {
 	// Arrange
 	secretKey := "test-secret-key-min-32-characters-long"
-	expiresIn := 24 // horas
-	service := jwt.NewJWTService(secretKey, expiresIn)
+	expiresIn := 15 // minutos
+	service, _ := jwt.NewJWTService(secretKey, testIssuer, testAudience, expiresIn, 720)
 
 	userID := "test-user-id"
-	token, err := service.GenerateToken(userID)
+	token, err := service.GenerateToken(domain.Principal{UserID: userID})
 	if err != nil {
 		t.Fatalf("Error generating token: %v", err)
 	}
 
 	// Act
-	validatedUserID, err := service.ValidateToken(token)
+	claims, err := service.ValidateToken(token)
 
 	// Assert
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	if validatedUserID != userID {
-		t.Errorf("Expected user ID %s, got %s", userID, validatedUserID)
+	if claims.UserID != userID {
+		t.Errorf("Expected user ID %s, got %s", userID, claims.UserID)
+	}
+
+	if claims.TokenID == "" {
+		t.Error("Expected token ID (jti), got empty string")
 	}
 }
 
 func TestJWTService_ValidateToken_InvalidToken(t *testing.T) {
 	// Arrange
 	secretKey := "test-secret-key-min-32-characters-long"
-	expiresIn := 24 // horas
-	service := jwt.NewJWTService(secretKey, expiresIn)
+	expiresIn := 15 // minutos
+	service, _ := jwt.NewJWTService(secretKey, testIssuer, testAudience, expiresIn, 720)
 
 	invalidToken := "invalid.token.here"
 
@@ -73,11 +85,11 @@ func TestJWTService_ValidateToken_InvalidToken(t *testing.T) {
 func TestJWTService_ValidateToken_ExpiredToken(t *testing.T) {
 	// Arrange
 	secretKey := "test-secret-key-min-32-characters-long"
-	expiresIn := -1 // token expirado (negativo)
-	service := jwt.NewJWTService(secretKey, expiresIn)
+	expiresIn := -1 // token expirado (negativo, minutos)
+	service, _ := jwt.NewJWTService(secretKey, testIssuer, testAudience, expiresIn, 720)
 
 	userID := "test-user-id"
-	token, err := service.GenerateToken(userID)
+	token, err := service.GenerateToken(domain.Principal{UserID: userID})
 	if err != nil {
 		t.Fatalf("Error generating token: %v", err)
 	}
@@ -101,11 +113,11 @@ func TestJWTService_ValidateToken_WrongSecret(t *testing.T) {
 	secretKey1 := "test-secret-key-min-32-characters-long-1"
 	secretKey2 := "test-secret-key-min-32-characters-long-2"
 	
-	service1 := jwt.NewJWTService(secretKey1, 24)
-	service2 := jwt.NewJWTService(secretKey2, 24)
+	service1, _ := jwt.NewJWTService(secretKey1, testIssuer, testAudience, 15, 720)
+	service2, _ := jwt.NewJWTService(secretKey2, testIssuer, testAudience, 15, 720)
 
 	userID := "test-user-id"
-	token, err := service1.GenerateToken(userID)
+	token, err := service1.GenerateToken(domain.Principal{UserID: userID})
 	if err != nil {
 		t.Fatalf("Error generating token: %v", err)
 	}
@@ -119,3 +131,159 @@ func TestJWTService_ValidateToken_WrongSecret(t *testing.T) {
 	}
 }
 
+func TestJWTService_GenerateRefreshToken(t *testing.T) {
+	// Arrange
+	service, _ := jwt.NewJWTService("test-secret-key-min-32-characters-long", testIssuer, testAudience, 15, 720)
+
+	// Act
+	first, expiresAt, err := service.GenerateRefreshToken()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	second, _, err := service.GenerateRefreshToken()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Assert
+	if first == "" || first == second {
+		t.Error("Expected unique, non-empty refresh tokens")
+	}
+
+	if !expiresAt.After(time.Now().Add(719 * time.Hour)) {
+		t.Errorf("Expected refresh token to expire in ~720h, got %v", expiresAt)
+	}
+}
+
+func TestJWTService_ValidateToken_RejectsAlgorithmMismatch(t *testing.T) {
+	// Arrange - token HS256 firmado con la misma cadena que usa otra instancia
+	service, _ := jwt.NewJWTService("test-secret-key-min-32-characters-long", testIssuer, testAudience, 15, 720)
+	token, err := service.GenerateToken(domain.Principal{UserID: "test-user-id"})
+	if err != nil {
+		t.Fatalf("Error generating token: %v", err)
+	}
+
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	key, _ := jwt.NewSigningKey("hs256", edKey)
+	keyring, _ := jwt.NewKeyring(key)
+	asymmetric := jwt.NewJWTServiceWithKeyring(keyring, testIssuer, testAudience, 15, 720)
+
+	// Act
+	_, err = asymmetric.ValidateToken(token)
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected error for token whose alg does not match the key, got nil")
+	}
+}
+
+func TestJWTService_ValidateToken_Principal(t *testing.T) {
+	// Arrange
+	service, _ := jwt.NewJWTService("test-secret-key-min-32-characters-long", testIssuer, testAudience, 15, 720)
+
+	token, err := service.GenerateToken(domain.Principal{
+		UserID:    "test-user-id",
+		SessionID: "test-session-id",
+		Roles:     []string{"admin"},
+		Scopes:    []string{"users:read", "users:write"},
+	})
+	if err != nil {
+		t.Fatalf("Error generating token: %v", err)
+	}
+
+	// Act
+	principal, err := service.ValidateToken(token)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if principal.UserID != "test-user-id" || principal.SessionID != "test-session-id" {
+		t.Errorf("Unexpected principal: %+v", principal)
+	}
+
+	if !principal.HasRole("admin") || !principal.HasScope("users:write") {
+		t.Errorf("Expected roles and scopes to round-trip, got %+v", principal)
+	}
+}
+
+func TestJWTService_ValidateToken_WrongIssuerOrAudience(t *testing.T) {
+	// Arrange
+	secretKey := "test-secret-key-min-32-characters-long"
+	service, _ := jwt.NewJWTService(secretKey, testIssuer, testAudience, 15, 720)
+
+	token, err := service.GenerateToken(domain.Principal{UserID: "test-user-id"})
+	if err != nil {
+		t.Fatalf("Error generating token: %v", err)
+	}
+
+	otherIssuer, _ := jwt.NewJWTService(secretKey, "https://other.example.com", testAudience, 15, 720)
+	otherAudience, _ := jwt.NewJWTService(secretKey, testIssuer, "billing-service", 15, 720)
+
+	// Act
+	_, errIssuer := otherIssuer.ValidateToken(token)
+	_, errAudience := otherAudience.ValidateToken(token)
+
+	// Assert
+	if errIssuer == nil {
+		t.Error("Expected error for token from another issuer, got nil")
+	}
+
+	if errAudience == nil {
+		t.Error("Expected error for token meant for another audience, got nil")
+	}
+}
+
+func TestJWTService_ValidateToken_ClientPrincipal(t *testing.T) {
+	// Arrange
+	service, _ := jwt.NewJWTService("test-secret-key-min-32-characters-long", testIssuer, testAudience, 15, 720)
+
+	token, err := service.GenerateToken(domain.Principal{
+		ClientID: "billing-service",
+		Scopes:   []string{"users:read"},
+	})
+	if err != nil {
+		t.Fatalf("Error generating token: %v", err)
+	}
+
+	// Act
+	principal, err := service.ValidateToken(token)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !principal.IsClient() || principal.ClientID != "billing-service" || principal.UserID != "" {
+		t.Errorf("Expected client principal without user, got %+v", principal)
+	}
+
+	if !principal.HasPermission("users:read") {
+		t.Errorf("Expected scopes to round-trip, got %+v", principal)
+	}
+}
+
+func TestJWTService_GenerateToken_MillisecondIssuedAt(t *testing.T) {
+	// Arrange
+	service, _ := jwt.NewJWTService("test-secret-key-min-32-characters-long", testIssuer, testAudience, 15, 720)
+	before := time.Now().Truncate(time.Millisecond)
+
+	token, err := service.GenerateToken(domain.Principal{UserID: "test-user-id"})
+	if err != nil {
+		t.Fatalf("Error generating token: %v", err)
+	}
+
+	// Act
+	principal, err := service.ValidateToken(token)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if principal.IssuedAt.Before(before) || principal.IssuedAt.After(time.Now()) {
+		t.Errorf("Expected iat with millisecond precision after %v, got %v", before, principal.IssuedAt)
+	}
+}
+
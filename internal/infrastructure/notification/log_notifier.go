@@ -0,0 +1,46 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/domain"
+	"go.uber.org/zap"
+)
+
+// logNotifier escribe los mensajes en el log en lugar de enviarlos. Sirve para
+// desarrollo mientras no haya un proveedor de email; en producción expone
+// tokens en los logs.
+type logNotifier struct {
+	logger *zap.Logger
+}
+
+func NewLogNotifier(logger *zap.Logger) domain.Notifier {
+	return &logNotifier{logger: logger}
+}
+
+func (n *logNotifier) SendPasswordReset(ctx context.Context, email, token string, expiresAt time.Time) error {
+	n.logger.Info("Enviando email de restablecimiento de contraseña",
+		zap.String("email", email),
+		zap.String("token", token),
+		zap.Time("expires_at", expiresAt),
+	)
+	return nil
+}
+
+func (n *logNotifier) SendEmailVerification(ctx context.Context, email, token string, expiresAt time.Time) error {
+	n.logger.Info("Enviando email de verificación",
+		zap.String("email", email),
+		zap.String("token", token),
+		zap.Time("expires_at", expiresAt),
+	)
+	return nil
+}
+
+func (n *logNotifier) SendAccountExists(ctx context.Context, email string) error {
+	n.logger.Info("Enviando aviso de registro con email existente",
+		zap.String("email", email),
+	)
+	return nil
+}
+
@@ -0,0 +1,192 @@
+package outbox
+
+import (
+	"context"
+	"expvar"
+	"time"
+
+	"user-service/internal/domain"
+
+	"go.uber.org/zap"
+)
+
+// Métricas del relay, publicadas con expvar en /debug/vars bajo "outbox".
+var (
+	publishedTotal = new(expvar.Int)
+	failedTotal    = new(expvar.Int)
+	pendingGauge   = new(expvar.Int)
+	stuckGauge     = new(expvar.Int)
+)
+
+func init() {
+	metrics := expvar.NewMap("outbox")
+	metrics.Set("published_total", publishedTotal)
+	metrics.Set("failed_total", failedTotal)
+	metrics.Set("pending", pendingGauge)
+	metrics.Set("stuck", stuckGauge)
+}
+
+type Config struct {
+	PollInterval   time.Duration // espera entre búsquedas de mensajes pendientes
+	BatchSize      int           // mensajes reclamados por consulta
+	PublishTimeout time.Duration // espera máxima de la confirmación del broker; 0 no limita
+	Lease          time.Duration // tiempo que un mensaje reclamado queda reservado
+	BaseBackoff    time.Duration // espera tras el primer fallo; se duplica en cada fallo
+	MaxBackoff     time.Duration // espera máxima entre reintentos
+	StatsInterval  time.Duration // cada cuánto se revisan los pendientes atascados
+	StuckAfter     time.Duration // antigüedad a partir de la cual un pendiente está atascado
+	Retention      time.Duration // tiempo que se conservan los enviados; 0 no los purga
+}
+
+// Relay publica los mensajes del outbox. La entrega es al menos una vez: si
+// el proceso muere entre publicar y marcar el mensaje como enviado, se vuelve
+// a publicar al vencer la reserva, así que los consumidores deben tolerar
+// duplicados.
+type Relay struct {
+	repo      domain.OutboxRepository
+	publisher domain.MessagePublisher
+	logger    *zap.Logger
+	config    Config
+}
+
+func NewRelay(repo domain.OutboxRepository, publisher domain.MessagePublisher, logger *zap.Logger, config Config) *Relay {
+	return &Relay{
+		repo:      repo,
+		publisher: publisher,
+		logger:    logger,
+		config:    config,
+	}
+}
+
+// Run publica los pendientes hasta que se cancela ctx.
+func (r *Relay) Run(ctx context.Context) {
+	poll := time.NewTicker(r.config.PollInterval)
+	defer poll.Stop()
+	stats := time.NewTicker(r.config.StatsInterval)
+	defer stats.Stop()
+
+	r.ReportStats(ctx)
+	for {
+		r.drain(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-poll.C:
+		case <-stats.C:
+			r.ReportStats(ctx)
+		}
+	}
+}
+
+// drain publica lotes mientras vengan llenos.
+func (r *Relay) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		relayed, err := r.RelayPending(ctx)
+		if err != nil {
+			r.logger.Error("Error al leer el outbox", zap.Error(err))
+			return
+		}
+		if relayed < r.config.BatchSize {
+			return
+		}
+	}
+}
+
+// RelayPending publica un lote de mensajes pendientes y devuelve cuántos
+// reclamó. Un fallo de publicación no es un error: el mensaje se reprograma
+// con backoff exponencial.
+func (r *Relay) RelayPending(ctx context.Context) (int, error) {
+	messages, err := r.repo.ClaimPending(ctx, time.Now(), r.config.BatchSize, r.config.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, message := range messages {
+		r.relay(ctx, message)
+	}
+
+	return len(messages), nil
+}
+
+func (r *Relay) relay(ctx context.Context, message *domain.OutboxMessage) {
+	id := message.ID.String()
+
+	publishCtx := ctx
+	if r.config.PublishTimeout > 0 {
+		var cancel context.CancelFunc
+		publishCtx, cancel = context.WithTimeout(ctx, r.config.PublishTimeout)
+		defer cancel()
+	}
+
+	if err := r.publisher.Publish(publishCtx, message.EventType, message.Payload); err != nil {
+		failedTotal.Add(1)
+		attempts := message.Attempts + 1
+		nextAttemptAt := time.Now().Add(r.backoff(attempts))
+		r.logger.Warn("Error al publicar mensaje del outbox",
+			zap.String("id", id),
+			zap.String("event_type", message.EventType),
+			zap.Int("attempts", attempts),
+			zap.Time("next_attempt_at", nextAttemptAt),
+			zap.Error(err),
+		)
+		if err := r.repo.MarkFailed(ctx, id, err.Error(), nextAttemptAt); err != nil {
+			r.logger.Error("Error al reprogramar mensaje del outbox", zap.String("id", id), zap.Error(err))
+		}
+		return
+	}
+
+	publishedTotal.Add(1)
+	if err := r.repo.MarkSent(ctx, id, time.Now()); err != nil {
+		// Se volverá a publicar al vencer la reserva.
+		r.logger.Error("Error al marcar mensaje del outbox como enviado", zap.String("id", id), zap.Error(err))
+	}
+}
+
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.config.BaseBackoff
+	for i := 1; i < attempts && delay < r.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > r.config.MaxBackoff {
+		delay = r.config.MaxBackoff
+	}
+	return delay
+}
+
+// ReportStats actualiza las métricas, avisa de los mensajes atascados y purga
+// los enviados fuera de la retención.
+func (r *Relay) ReportStats(ctx context.Context) {
+	now := time.Now()
+
+	stats, err := r.repo.Stats(ctx, now.Add(-r.config.StuckAfter))
+	if err != nil {
+		r.logger.Error("Error al consultar estado del outbox", zap.Error(err))
+	} else {
+		pendingGauge.Set(stats.Pending)
+		stuckGauge.Set(stats.Stuck)
+		if stats.Stuck > 0 {
+			fields := []zap.Field{
+				zap.Int64("stuck", stats.Stuck),
+				zap.Int64("pending", stats.Pending),
+			}
+			if stats.OldestPending != nil {
+				fields = append(fields, zap.Duration("oldest_age", now.Sub(*stats.OldestPending)))
+			}
+			r.logger.Warn("Mensajes atascados en el outbox", fields...)
+		}
+	}
+
+	if r.config.Retention <= 0 {
+		return
+	}
+	purged, err := r.repo.DeleteSentBefore(ctx, now.Add(-r.config.Retention))
+	if err != nil {
+		r.logger.Error("Error al purgar el outbox", zap.Error(err))
+		return
+	}
+	if purged > 0 {
+		r.logger.Info("Mensajes enviados purgados del outbox", zap.Int64("purged", purged))
+	}
+}
+
@@ -0,0 +1,226 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/infrastructure/outbox"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type memoryOutboxRepository struct {
+	messages map[string]*domain.OutboxMessage
+}
+
+func newMemoryOutboxRepository(messages ...*domain.OutboxMessage) *memoryOutboxRepository {
+	repo := &memoryOutboxRepository{messages: make(map[string]*domain.OutboxMessage)}
+	for _, message := range messages {
+		message.ID = uuid.New()
+		repo.messages[message.ID.String()] = message
+	}
+	return repo
+}
+
+func (m *memoryOutboxRepository) ClaimPending(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*domain.OutboxMessage, error) {
+	var claimed []*domain.OutboxMessage
+	for _, message := range m.messages {
+		if !message.IsSent() && !message.NextAttemptAt.After(now) {
+			claimed = append(claimed, message)
+		}
+	}
+	sort.Slice(claimed, func(i, j int) bool { return claimed[i].CreatedAt.Before(claimed[j].CreatedAt) })
+	if len(claimed) > limit {
+		claimed = claimed[:limit]
+	}
+	for _, message := range claimed {
+		message.NextAttemptAt = now.Add(lease)
+	}
+	return claimed, nil
+}
+
+func (m *memoryOutboxRepository) MarkSent(ctx context.Context, id string, sentAt time.Time) error {
+	message := m.messages[id]
+	message.Attempts++
+	message.SentAt = &sentAt
+	return nil
+}
+
+func (m *memoryOutboxRepository) MarkFailed(ctx context.Context, id string, lastError string, nextAttemptAt time.Time) error {
+	message := m.messages[id]
+	message.Attempts++
+	message.LastError = lastError
+	message.NextAttemptAt = nextAttemptAt
+	return nil
+}
+
+func (m *memoryOutboxRepository) Stats(ctx context.Context, stuckBefore time.Time) (*domain.OutboxStats, error) {
+	stats := &domain.OutboxStats{}
+	for _, message := range m.messages {
+		if message.IsSent() {
+			continue
+		}
+		stats.Pending++
+		if message.CreatedAt.Before(stuckBefore) {
+			stats.Stuck++
+		}
+		if stats.OldestPending == nil || message.CreatedAt.Before(*stats.OldestPending) {
+			createdAt := message.CreatedAt
+			stats.OldestPending = &createdAt
+		}
+	}
+	return stats, nil
+}
+
+func (m *memoryOutboxRepository) DeleteSentBefore(ctx context.Context, before time.Time) (int64, error) {
+	var deleted int64
+	for id, message := range m.messages {
+		if message.IsSent() && message.SentAt.Before(before) {
+			delete(m.messages, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+type recordingPublisher struct {
+	err       error
+	published []string
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, eventType string, body []byte) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.published = append(p.published, eventType+" "+string(body))
+	return nil
+}
+
+func newPendingMessage(t *testing.T, createdAt time.Time) *domain.OutboxMessage {
+	t.Helper()
+	message, err := domain.NewOutboxMessage(context.Background(), domain.EventUserCreated, &domain.UserCreatedPayload{UserID: "u-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	message.CreatedAt = createdAt
+	return message
+}
+
+func relayConfig() outbox.Config {
+	return outbox.Config{
+		PollInterval:  time.Second,
+		BatchSize:     10,
+		Lease:         30 * time.Second,
+		BaseBackoff:   time.Second,
+		MaxBackoff:    4 * time.Second,
+		StatsInterval: time.Minute,
+		StuckAfter:    5 * time.Minute,
+	}
+}
+
+func TestRelay_RelayPending_PublishesAndMarksSent(t *testing.T) {
+	// Arrange
+	message := newPendingMessage(t, time.Now())
+	repo := newMemoryOutboxRepository(message)
+	publisher := &recordingPublisher{}
+
+	relay := outbox.NewRelay(repo, publisher, zap.NewNop(), relayConfig())
+
+	// Act
+	relayed, err := relay.RelayPending(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if relayed != 1 || len(publisher.published) != 1 {
+		t.Fatalf("Expected one message published, got %d", len(publisher.published))
+	}
+
+	if publisher.published[0] != "user.created "+string(message.Payload) {
+		t.Errorf("Unexpected message %q", publisher.published[0])
+	}
+
+	if !message.IsSent() {
+		t.Error("Expected message to be marked as sent")
+	}
+
+	relayed, _ = relay.RelayPending(context.Background())
+	if relayed != 0 {
+		t.Errorf("Expected sent message not to be relayed again, got %d", relayed)
+	}
+}
+
+func TestRelay_RelayPending_PublishFailureBacksOff(t *testing.T) {
+	// Arrange
+	message := newPendingMessage(t, time.Now())
+	repo := newMemoryOutboxRepository(message)
+	publisher := &recordingPublisher{err: errors.New("conexión cerrada")}
+
+	relay := outbox.NewRelay(repo, publisher, zap.NewNop(), relayConfig())
+
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second} {
+		// Act
+		message.NextAttemptAt = time.Now()
+		before := time.Now()
+		if _, err := relay.RelayPending(context.Background()); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Assert
+		if message.IsSent() || message.Attempts != attempt+1 || message.LastError != "conexión cerrada" {
+			t.Fatalf("Unexpected message after attempt %d: %+v", attempt+1, message)
+		}
+
+		delay := message.NextAttemptAt.Sub(before)
+		if delay < want || delay > want+time.Second {
+			t.Errorf("Attempt %d: expected backoff of %v, got %v", attempt+1, want, delay)
+		}
+	}
+
+	relayed, _ := relay.RelayPending(context.Background())
+	if relayed != 0 {
+		t.Errorf("Expected no retry before the backoff expires, got %d", relayed)
+	}
+}
+
+func TestRelay_ReportStats_WarnsAboutStuckMessages(t *testing.T) {
+	// Arrange
+	stuck := newPendingMessage(t, time.Now().Add(-10*time.Minute))
+	fresh := newPendingMessage(t, time.Now())
+	sentAt := time.Now().Add(-48 * time.Hour)
+	old := newPendingMessage(t, sentAt)
+	old.SentAt = &sentAt
+	repo := newMemoryOutboxRepository(stuck, fresh, old)
+
+	core, logs := observer.New(zap.InfoLevel)
+	config := relayConfig()
+	config.Retention = 24 * time.Hour
+
+	relay := outbox.NewRelay(repo, &recordingPublisher{}, zap.New(core), config)
+
+	// Act
+	relay.ReportStats(context.Background())
+
+	// Assert
+	warnings := logs.FilterMessage("Mensajes atascados en el outbox").All()
+	if len(warnings) != 1 {
+		t.Fatalf("Expected one stuck warning, got %d", len(warnings))
+	}
+
+	if stuckCount := warnings[0].ContextMap()["stuck"]; stuckCount != int64(1) {
+		t.Errorf("Expected 1 stuck message, got %v", stuckCount)
+	}
+
+	if len(repo.messages) != 2 {
+		t.Errorf("Expected the old sent message to be purged, got %d messages", len(repo.messages))
+	}
+}
+
@@ -2,58 +2,126 @@ package rabbitmq
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"sync"
 	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 	"user-service/internal/domain"
 )
 
+const (
+	consumerTag      = "user-service"
+	republishTimeout = 10 * time.Second
+)
+
+type ConsumerConfig struct {
+	Prefetch          int           // mensajes sin confirmar que el broker entrega por adelantado
+	Workers           int           // handlers que procesan mensajes en paralelo
+	ReconnectDelay    time.Duration // espera tras el primer intento fallido; se duplica en cada fallo
+	MaxReconnectDelay time.Duration // espera máxima entre intentos de reconexión
+	Retry             RetryPolicy   // reintentos de los mensajes cuyo handler falla
+}
+
+// eventConsumer se reconecta solo si se cierra la conexión o el canal. Al
+// cancelarse el contexto deja de recibir entregas y espera a que terminen los
+// handlers en curso; los mensajes sin confirmar vuelven a la cola.
+//
+// Un mensaje cuyo handler falla pasa por las colas de espera de Retry y, al
+// agotar los intentos, queda en ParkedQueue junto con los que no se pueden
+// interpretar.
 type eventConsumer struct {
-	conn      *amqp.Connection
-	channel   *amqp.Channel
+	amqpURL   string
 	queueName string
+	config    ConsumerConfig
+
+	mu      sync.Mutex
+	conn    *amqp.Connection
+	channel *amqp.Channel
 }
 
-func NewEventConsumer(amqpURL, queueName string) (domain.EventConsumer, error) {
-	conn, err := amqp.Dial(amqpURL)
-	if err != nil {
-		return nil, fmt.Errorf("error al conectar con RabbitMQ: %w", err)
+func NewEventConsumer(amqpURL, queueName string, config ConsumerConfig) (domain.EventConsumer, error) {
+	if config.Prefetch < 1 {
+		config.Prefetch = 1
+	}
+	if config.Workers < 1 {
+		config.Workers = 1
+	}
+	if config.ReconnectDelay <= 0 {
+		config.ReconnectDelay = time.Second
+	}
+	if config.MaxReconnectDelay < config.ReconnectDelay {
+		config.MaxReconnectDelay = config.ReconnectDelay
+	}
+	if config.Retry.MaxAttempts < 1 {
+		config.Retry.MaxAttempts = 1
+	}
+	if config.Retry.BaseDelay < time.Second {
+		config.Retry.BaseDelay = time.Second
+	}
+	if config.Retry.MaxDelay < config.Retry.BaseDelay {
+		config.Retry.MaxDelay = config.Retry.BaseDelay
 	}
 
-	ch, err := conn.Channel()
-	if err != nil {
-		conn.Close()
-		return nil, fmt.Errorf("error al abrir canal: %w", err)
+	c := &eventConsumer{
+		amqpURL:   amqpURL,
+		queueName: queueName,
+		config:    config,
 	}
 
-	_, err = ch.QueueDeclare(
-		queueName, // nombre
-		true,      // durable
-		false,     // delete when unused
-		false,     // exclusive
-		false,     // no-wait
-		nil,       // arguments
-	)
-	if err != nil {
-		ch.Close()
-		conn.Close()
-		return nil, fmt.Errorf("error al declarar cola: %w", err)
+	if _, err := c.connect(); err != nil {
+		return nil, err
 	}
 
-	return &eventConsumer{
-		conn:      conn,
-		channel:   ch,
-		queueName: queueName,
-	}, nil
+	return c, nil
+}
+
+// ConsumeUserCreated bloquea hasta que se cancela ctx y entonces devuelve nil
+// tras drenar los handlers en curso. Los cortes de conexión no terminan el
+// consumo: se reintenta con espera exponencial.
+func (c *eventConsumer) ConsumeUserCreated(ctx context.Context, handler func(ctx context.Context, event *domain.EventEnvelope, payload *domain.UserCreatedPayload) error) error {
+	delay := c.config.ReconnectDelay
+	for {
+		consumed, err := c.consume(ctx, handler)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if consumed {
+			delay = c.config.ReconnectDelay
+		}
+
+		log.Printf("Consumidor de %s desconectado, reintento en %s: %v", c.queueName, delay, err)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > c.config.MaxReconnectDelay {
+			delay = c.config.MaxReconnectDelay
+		}
+	}
 }
 
-func (c *eventConsumer) ConsumeUserCreated(ctx context.Context, handler func(userID, email string, createdAt int64) error) error {
-	msgs, err := c.channel.Consume(
+// consume atiende una conexión hasta que se pierde o se cancela ctx. Devuelve
+// true si llegó a registrarse como consumidor.
+func (c *eventConsumer) consume(ctx context.Context, handler func(ctx context.Context, event *domain.EventEnvelope, payload *domain.UserCreatedPayload) error) (bool, error) {
+	ch, err := c.connect()
+	if err != nil {
+		return false, err
+	}
+	defer c.disconnect()
+
+	if err := ch.Qos(c.config.Prefetch, 0, false); err != nil {
+		return false, fmt.Errorf("error al configurar prefetch: %w", err)
+	}
+
+	msgs, err := ch.Consume(
 		c.queueName, // queue
-		"",          // consumer
+		consumerTag, // consumer
 		false,       // auto-ack (manual ack)
 		false,       // exclusive
 		false,       // no-local
@@ -61,74 +129,219 @@ func (c *eventConsumer) ConsumeUserCreated(ctx context.Context, handler func(use
 		nil,         // args
 	)
 	if err != nil {
-		return fmt.Errorf("error al registrar consumidor: %w", err)
+		return false, fmt.Errorf("error al registrar consumidor: %w", err)
 	}
 
-	log.Printf("Consumiendo mensajes de la cola: %s", c.queueName)
+	log.Printf("Consumiendo mensajes de la cola: %s (prefetch=%d, workers=%d)", c.queueName, c.config.Prefetch, c.config.Workers)
 
-	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case msg, ok := <-msgs:
-			if !ok {
-		return fmt.Errorf("canal de mensajes cerrado")
+	var wg sync.WaitGroup
+	for i := 0; i < c.config.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for msg := range msgs {
+				c.handleUserCreated(ch, msg, handler)
+			}
+		}()
+	}
+
+	drained := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-ctx.Done():
+		// Tras cancelar, la librería entrega lo ya recibido y cierra msgs
+		if err := ch.Cancel(consumerTag, false); err != nil {
+			log.Printf("Error al cancelar consumidor: %v", err)
 		}
+		<-drained
+		log.Printf("Consumidor de %s detenido", c.queueName)
+		return true, nil
+	case <-drained:
+		return true, errors.New("canal de mensajes cerrado")
+	}
+}
 
-		var event map[string]interface{}
-			if err := json.Unmarshal(msg.Body, &event); err != nil {
-				log.Printf("Error al parsear mensaje: %v", err)
-				msg.Nack(false, false)
-				continue
-			}
+// handleUserCreated lee también los mensajes anteriores al sobre. El handler
+// recibe un contexto con el correlation ID del evento.
+func (c *eventConsumer) handleUserCreated(ch *amqp.Channel, msg amqp.Delivery, handler func(ctx context.Context, event *domain.EventEnvelope, payload *domain.UserCreatedPayload) error) {
+	event, err := domain.DecodeEvent(msg.Body, domain.EventUserCreated)
+	if err != nil {
+		c.park(ch, msg, attemptsFrom(msg.Headers), err)
+		return
+	}
 
-		userID, ok := event["user_id"].(string)
-			if !ok {
-				log.Printf("Error: user_id no es string")
-				msg.Nack(false, false)
-				continue
-			}
+	if event.Type != domain.EventUserCreated {
+		c.park(ch, msg, attemptsFrom(msg.Headers), fmt.Errorf("evento %s inesperado en %s", event.Type, c.queueName))
+		return
+	}
 
-			email, ok := event["email"].(string)
-			if !ok {
-				log.Printf("Error: email no es string")
-				msg.Nack(false, false)
-				continue
-			}
+	var payload domain.UserCreatedPayload
+	if err := event.DecodePayload(&payload); err != nil {
+		c.park(ch, msg, attemptsFrom(msg.Headers), err)
+		return
+	}
 
-			createdAtStr, ok := event["created_at"].(string)
-			if !ok {
-				log.Printf("Error: created_at no es string")
-				msg.Nack(false, false)
-				continue
-			}
+	if err := payload.Validate(); err != nil {
+		c.park(ch, msg, attemptsFrom(msg.Headers), err)
+		return
+	}
 
-		createdAt, err := time.Parse(time.RFC3339, createdAtStr)
-			if err != nil {
-				log.Printf("Error al parsear fecha: %v", err)
-				msg.Nack(false, false)
-				continue
-			}
+	ctx := domain.WithCorrelationID(context.Background(), event.CorrelationID)
+	if err := handler(ctx, event, &payload); err != nil {
+		c.retry(ch, msg, err)
+		return
+	}
 
-			if err := handler(userID, email, createdAt.Unix()); err != nil {
-				log.Printf("Error en handler: %v", err)
-				msg.Nack(false, true)
-				continue
-			}
+	msg.Ack(false)
+	log.Printf("Evento procesado: id=%s, user_id=%s, email=%s", event.ID, payload.UserID, payload.Email)
+}
 
-		msg.Ack(false)
-			log.Printf("Evento procesado: user_id=%s, email=%s", userID, email)
-		}
+// retry mueve el mensaje a la cola de espera de su intento o lo aparca si
+// agotó los intentos o el error es permanente. Si no puede moverlo lo
+// devuelve a la cola.
+func (c *eventConsumer) retry(ch *amqp.Channel, msg amqp.Delivery, cause error) {
+	retryQueue, attempts := c.config.Retry.NextQueue(c.queueName, attemptsFrom(msg.Headers), cause)
+	if retryQueue == ParkedQueue(c.queueName) {
+		c.park(ch, msg, attempts, cause)
+		return
+	}
+
+	if err := republish(ch, retryQueue, msg, attempts, cause.Error()); err != nil {
+		log.Printf("Error al reprogramar mensaje: %v", err)
+		msg.Nack(false, true)
+		return
 	}
+
+	msg.Ack(false)
+	log.Printf("Error en handler, reintento %d de %d en %s: %v",
+		attempts+1, c.config.Retry.MaxAttempts, c.config.Retry.Delay(attempts), cause)
 }
 
-func (c *eventConsumer) Close() error {
+// park mueve el mensaje a la cola de aparcados con el error que lo llevó
+// allí. Si no puede, lo rechaza y el dead-letter de la cola lo aparca igual,
+// aunque sin x-last-error.
+func (c *eventConsumer) park(ch *amqp.Channel, msg amqp.Delivery, attempts int, cause error) {
+	log.Printf("Mensaje aparcado en %s tras %d intentos: %v", ParkedQueue(c.queueName), attempts, cause)
+
+	if err := republish(ch, ParkedQueue(c.queueName), msg, attempts, cause.Error()); err != nil {
+		log.Printf("Error al aparcar mensaje, se usa el dead-letter: %v", err)
+		msg.Nack(false, false)
+		return
+	}
+
+	msg.Ack(false)
+}
+
+// republish copia el mensaje a queueName con las cabeceras de intentos y
+// espera la confirmación del broker antes de que se confirme el original.
+func republish(ch *amqp.Channel, queueName string, msg amqp.Delivery, attempts int, lastError string) error {
+	headers := amqp.Table{}
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	headers[HeaderAttempts] = int32(attempts)
+	headers[HeaderLastError] = lastError
+
+	ctx, cancel := context.WithTimeout(context.Background(), republishTimeout)
+	defer cancel()
+
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(
+		ctx,
+		"",        // exchange
+		queueName, // routing key
+		false,     // mandatory
+		false,     // immediate
+		amqp.Publishing{
+			Headers:      headers,
+			ContentType:  msg.ContentType,
+			DeliveryMode: amqp.Persistent,
+			MessageId:    msg.MessageId,
+			Timestamp:    msg.Timestamp,
+			Body:         msg.Body,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error al publicar en %s: %w", queueName, err)
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("sin confirmación de RabbitMQ: %w", err)
+	}
+	if !acked {
+		return ErrPublishNacked
+	}
+	return nil
+}
+
+// connect reutiliza el canal abierto o abre una conexión nueva.
+func (c *eventConsumer) connect() (*amqp.Channel, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.channel != nil && !c.channel.IsClosed() {
+		return c.channel, nil
+	}
+	c.closeLocked()
+
+	conn, err := amqp.Dial(c.amqpURL)
+	if err != nil {
+		return nil, fmt.Errorf("error al conectar con RabbitMQ: %w", err)
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error al abrir canal: %w", err)
+	}
+
+	// Confirmaciones para no perder mensajes al moverlos a reintentos o aparcados
+	if err := ch.Confirm(false); err != nil {
+		ch.Close()
+		conn.Close()
+		return nil, fmt.Errorf("error al activar confirmaciones: %w", err)
+	}
+
+	err = declareEventQueue(ch, c.queueName)
+	if err == nil {
+		err = declareRetryQueues(ch, c.queueName, c.config.Retry)
+	}
+	if err != nil {
+		ch.Close()
+		conn.Close()
+		return nil, err
+	}
+
+	c.conn = conn
+	c.channel = ch
+	return ch, nil
+}
+
+func (c *eventConsumer) disconnect() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closeLocked()
+}
+
+func (c *eventConsumer) closeLocked() {
 	if c.channel != nil {
 		c.channel.Close()
 	}
 	if c.conn != nil {
-		return c.conn.Close()
+		c.conn.Close()
 	}
+	c.channel = nil
+	c.conn = nil
+}
+
+// Close cierra la conexión; llamarlo antes de que ConsumeUserCreated termine
+// corta el drenaje y los mensajes sin confirmar vuelven a la cola.
+func (c *eventConsumer) Close() error {
+	c.disconnect()
 	return nil
 }
 
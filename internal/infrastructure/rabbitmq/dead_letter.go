@@ -0,0 +1,166 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// ParkedMessage es un mensaje de la cola de aparcados tal como lo muestra el
+// comando dlq.
+type ParkedMessage struct {
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"last_error,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+	Body      string    `json:"body"`
+}
+
+// DeadLetterAdmin inspecciona y reenvía los mensajes aparcados de una cola.
+type DeadLetterAdmin struct {
+	conn      *amqp.Connection
+	channel   *amqp.Channel
+	queueName string
+}
+
+func NewDeadLetterAdmin(amqpURL, queueName string) (*DeadLetterAdmin, error) {
+	conn, err := amqp.Dial(amqpURL)
+	if err != nil {
+		return nil, fmt.Errorf("error al conectar con RabbitMQ: %w", err)
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error al abrir canal: %w", err)
+	}
+
+	if err := ch.Confirm(false); err != nil {
+		ch.Close()
+		conn.Close()
+		return nil, fmt.Errorf("error al activar confirmaciones: %w", err)
+	}
+
+	if err := declareEventQueue(ch, queueName); err != nil {
+		ch.Close()
+		conn.Close()
+		return nil, err
+	}
+
+	return &DeadLetterAdmin{
+		conn:      conn,
+		channel:   ch,
+		queueName: queueName,
+	}, nil
+}
+
+// List devuelve hasta limit mensajes aparcados sin sacarlos de la cola.
+func (a *DeadLetterAdmin) List(limit int) ([]ParkedMessage, error) {
+	var messages []ParkedMessage
+	var last *amqp.Delivery
+
+	for len(messages) < limit {
+		msg, ok, err := a.channel.Get(ParkedQueue(a.queueName), false)
+		if err != nil {
+			return nil, fmt.Errorf("error al leer mensajes aparcados: %w", err)
+		}
+		if !ok {
+			break
+		}
+		last = &msg
+
+		lastError, _ := msg.Headers[HeaderLastError].(string)
+		messages = append(messages, ParkedMessage{
+			Attempts:  attemptsFrom(msg.Headers),
+			LastError: lastError,
+			Timestamp: msg.Timestamp,
+			Body:      string(msg.Body),
+		})
+	}
+
+	// Devuelve todos los leídos a la cola, en su posición original
+	if last != nil {
+		if err := last.Nack(true, true); err != nil {
+			return nil, fmt.Errorf("error al devolver mensajes aparcados: %w", err)
+		}
+	}
+
+	return messages, nil
+}
+
+// Replay reenvía a la cola original hasta limit mensajes aparcados, con los
+// intentos a cero, y devuelve cuántos reenvió.
+func (a *DeadLetterAdmin) Replay(ctx context.Context, limit int) (int, error) {
+	replayed := 0
+	for replayed < limit {
+		msg, ok, err := a.channel.Get(ParkedQueue(a.queueName), false)
+		if err != nil {
+			return replayed, fmt.Errorf("error al leer mensajes aparcados: %w", err)
+		}
+		if !ok {
+			break
+		}
+
+		if err := a.replay(ctx, msg); err != nil {
+			msg.Nack(false, true)
+			return replayed, err
+		}
+
+		if err := msg.Ack(false); err != nil {
+			return replayed, fmt.Errorf("error al confirmar mensaje aparcado: %w", err)
+		}
+		replayed++
+	}
+
+	return replayed, nil
+}
+
+func (a *DeadLetterAdmin) replay(ctx context.Context, msg amqp.Delivery) error {
+	headers := amqp.Table{}
+	for k, v := range msg.Headers {
+		if k == HeaderAttempts || k == HeaderLastError || k == "x-death" {
+			continue
+		}
+		headers[k] = v
+	}
+
+	confirmation, err := a.channel.PublishWithDeferredConfirmWithContext(
+		ctx,
+		"",          // exchange
+		a.queueName, // routing key
+		false,       // mandatory
+		false,       // immediate
+		amqp.Publishing{
+			Headers:      headers,
+			ContentType:  msg.ContentType,
+			DeliveryMode: amqp.Persistent,
+			MessageId:    msg.MessageId,
+			Timestamp:    msg.Timestamp,
+			Body:         msg.Body,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error al reenviar mensaje: %w", err)
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("sin confirmación de RabbitMQ: %w", err)
+	}
+	if !acked {
+		return ErrPublishNacked
+	}
+	return nil
+}
+
+func (a *DeadLetterAdmin) Close() error {
+	if a.channel != nil {
+		a.channel.Close()
+	}
+	if a.conn != nil {
+		return a.conn.Close()
+	}
+	return nil
+}
+
@@ -2,92 +2,378 @@ package rabbitmq
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"sync"
 	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 	"user-service/internal/domain"
 )
 
+var (
+	ErrPublisherClosed   = errors.New("publisher de RabbitMQ cerrado")
+	ErrPublishBufferFull = errors.New("buffer de publicación lleno mientras se reconecta con RabbitMQ")
+	ErrPublishNacked     = errors.New("RabbitMQ rechazó el mensaje")
+
+	errConnectionLost = errors.New("conexión con RabbitMQ perdida")
+)
+
+type PublisherConfig struct {
+	BufferSize        int           // publicaciones que pueden esperar a que vuelva la conexión
+	ReconnectDelay    time.Duration // espera tras el primer intento fallido; se duplica en cada fallo
+	MaxReconnectDelay time.Duration // espera máxima entre intentos de reconexión
+}
+
+// eventPublisher publica en modo confirm: Publish solo devuelve nil cuando el
+// broker confirma que guardó el mensaje. Una sola goroutine es dueña de la
+// conexión; las publicaciones le llegan por requests, que hace de buffer
+// acotado mientras se reconecta tras un cierre de la conexión o del canal.
+// Al cerrar, closing deja de aceptar publicaciones y cancelar aborted
+// interrumpe el vaciado del buffer.
 type eventPublisher struct {
-	conn    *amqp.Connection
-	channel *amqp.Channel
+	amqpURL   string
 	queueName string
+	config    PublisherConfig
+
+	requests  chan *publishRequest
+	closing   chan struct{}
+	closeOnce sync.Once
+	aborted   context.Context
+	abort     context.CancelFunc
+	stopped   chan struct{}
+
+	// Solo los usa la goroutine de run.
+	conn     *amqp.Connection
+	channel  *amqp.Channel
+	closed   chan *amqp.Error
+	declared map[string]bool
 }
 
-func NewEventPublisher(amqpURL, queueName string) (domain.EventPublisher, error) {
-	conn, err := amqp.Dial(amqpURL)
-	if err != nil {
-		return nil, fmt.Errorf("error al conectar con RabbitMQ: %w", err)
+type publishRequest struct {
+	ctx       context.Context
+	queueName string
+	body      []byte
+	result    chan error
+}
+
+func NewEventPublisher(amqpURL, queueName string, config PublisherConfig) (domain.EventPublisher, error) {
+	if config.BufferSize < 0 {
+		config.BufferSize = 0
+	}
+	if config.ReconnectDelay <= 0 {
+		config.ReconnectDelay = time.Second
+	}
+	if config.MaxReconnectDelay < config.ReconnectDelay {
+		config.MaxReconnectDelay = config.ReconnectDelay
 	}
 
-	ch, err := conn.Channel()
-	if err != nil {
-		conn.Close()
-		return nil, fmt.Errorf("error al abrir canal: %w", err)
+	p := &eventPublisher{
+		amqpURL:   amqpURL,
+		queueName: queueName,
+		config:    config,
+		requests:  make(chan *publishRequest, config.BufferSize),
+		closing:   make(chan struct{}),
+		stopped:   make(chan struct{}),
 	}
+	p.aborted, p.abort = context.WithCancel(context.Background())
 
-	_, err = ch.QueueDeclare(
-		queueName, // nombre
-		true,      // durable
-		false,     // delete when unused
-		false,     // exclusive
-		false,     // no-wait
-		nil,       // arguments
-	)
-	if err != nil {
-		ch.Close()
-		conn.Close()
-		return nil, fmt.Errorf("error al declarar cola: %w", err)
+	if err := p.connect(); err != nil {
+		return nil, err
 	}
 
-	return &eventPublisher{
-		conn:      conn,
-		channel:   ch,
-		queueName: queueName,
-	}, nil
+	go p.run()
+
+	return p, nil
 }
 
 func (p *eventPublisher) PublishUserCreated(ctx context.Context, userID, email string, createdAt int64) error {
-	event := map[string]interface{}{
-		"user_id":    userID,
-		"email":      email,
-		"created_at": time.Unix(createdAt, 0).Format(time.RFC3339),
+	return p.publishEvent(ctx, domain.EventUserCreated, domain.UserCreatedPayload{
+		UserID:    userID,
+		Email:     email,
+		CreatedAt: time.Unix(createdAt, 0).UTC(),
+	})
+}
+
+func (p *eventPublisher) PublishUserUpdated(ctx context.Context, userID, email string, updatedAt int64) error {
+	return p.publishEvent(ctx, domain.EventUserUpdated, domain.UserUpdatedPayload{
+		UserID:    userID,
+		Email:     email,
+		UpdatedAt: time.Unix(updatedAt, 0).UTC(),
+	})
+}
+
+func (p *eventPublisher) PublishPasswordChanged(ctx context.Context, userID string, changedAt int64) error {
+	return p.publishEvent(ctx, domain.EventPasswordChanged, domain.PasswordChangedPayload{
+		UserID:    userID,
+		ChangedAt: time.Unix(changedAt, 0).UTC(),
+	})
+}
+
+func (p *eventPublisher) PublishUserLocked(ctx context.Context, userID, email string, lockedUntil int64) error {
+	return p.publishEvent(ctx, domain.EventUserLocked, domain.UserLockedPayload{
+		UserID:      userID,
+		Email:       email,
+		LockedUntil: time.Unix(lockedUntil, 0).UTC(),
+	})
+}
+
+// Publish envía un evento ya serializado. user.created va a la cola
+// configurada; el resto, a la cola con el nombre del evento.
+func (p *eventPublisher) Publish(ctx context.Context, eventType string, body []byte) error {
+	queueName := eventType
+	if eventType == domain.EventUserCreated {
+		queueName = p.queueName
 	}
+	return p.publishBody(ctx, queueName, body)
+}
 
-	body, err := json.Marshal(event)
+// publishEvent envuelve el payload en un domain.EventEnvelope.
+func (p *eventPublisher) publishEvent(ctx context.Context, eventType string, payload interface{}) error {
+	body, err := domain.EncodeEvent(ctx, eventType, payload)
 	if err != nil {
-		return fmt.Errorf("error al serializar evento: %w", err)
+		return err
+	}
+
+	return p.Publish(ctx, eventType, body)
+}
+
+// publishBody deja la publicación en el buffer y espera la confirmación. Si
+// el buffer está lleno falla de inmediato en lugar de bloquear al llamador.
+func (p *eventPublisher) publishBody(ctx context.Context, queueName string, body []byte) error {
+	req := &publishRequest{
+		ctx:       ctx,
+		queueName: queueName,
+		body:      body,
+		result:    make(chan error, 1),
+	}
+
+	select {
+	case <-p.closing:
+		return ErrPublisherClosed
+	default:
+	}
+
+	select {
+	case p.requests <- req:
+	default:
+		return ErrPublishBufferFull
+	}
+
+	select {
+	case err := <-req.result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-p.stopped:
+		return ErrPublisherClosed
+	}
+}
+
+func (p *eventPublisher) run() {
+	defer close(p.stopped)
+	defer p.disconnect()
+
+	var retry *publishRequest
+	for {
+		if p.isClosing() && retry == nil && len(p.requests) == 0 {
+			return
+		}
+
+		if p.channel == nil && !p.reconnect() {
+			return
+		}
+
+		req := retry
+		retry = nil
+		if req == nil {
+			select {
+			case <-p.aborted.Done():
+				return
+			case <-p.closing:
+				// Se vacía lo que queda en el buffer
+				continue
+			case err := <-p.closed:
+				log.Printf("Conexión del publisher de RabbitMQ cerrada: %v", err)
+				p.disconnect()
+				continue
+			case req = <-p.requests:
+			}
+		}
+
+		// El llamador ya no espera el resultado
+		if req.ctx.Err() != nil {
+			continue
+		}
+
+		err := p.publishConfirmed(req)
+		if errors.Is(err, errConnectionLost) {
+			// Se reintenta con la nueva conexión antes que el resto del buffer
+			log.Printf("Conexión del publisher de RabbitMQ perdida al publicar en %s", req.queueName)
+			p.disconnect()
+			retry = req
+			continue
+		}
+		req.result <- err
+
+		// Un error de canal (por ejemplo, al declarar una cola) lo cierra
+		if p.channel.IsClosed() {
+			p.disconnect()
+		}
 	}
+}
+
+func (p *eventPublisher) publishConfirmed(req *publishRequest) error {
+	if err := p.declareQueue(req.queueName); err != nil {
+		return err
+	}
+
+	// Al abortar el cierre no se espera más la confirmación
+	ctx, cancel := context.WithCancel(req.ctx)
+	defer cancel()
+	stop := context.AfterFunc(p.aborted, cancel)
+	defer stop()
 
-	err = p.channel.PublishWithContext(
+	confirmation, err := p.channel.PublishWithDeferredConfirmWithContext(
 		ctx,
-		"",           // exchange
-		p.queueName,  // routing key
-		false,        // mandatory
-		false,        // immediate
+		"",            // exchange
+		req.queueName, // routing key
+		false,         // mandatory
+		false,         // immediate
 		amqp.Publishing{
-			ContentType: "application/json",
-			Body:        body,
-			Timestamp:   time.Now(),
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			Body:         req.body,
+			Timestamp:    time.Now(),
 		},
 	)
-
 	if err != nil {
+		if p.channel.IsClosed() {
+			return errConnectionLost
+		}
 		return fmt.Errorf("error al publicar mensaje: %w", err)
 	}
 
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("sin confirmación de RabbitMQ: %w", err)
+	}
+	if !acked {
+		// Al cerrarse el canal las confirmaciones pendientes llegan como nack
+		if p.channel.IsClosed() {
+			return errConnectionLost
+		}
+		return ErrPublishNacked
+	}
+
 	return nil
 }
 
-func (p *eventPublisher) Close() error {
+func (p *eventPublisher) connect() error {
+	conn, err := amqp.Dial(p.amqpURL)
+	if err != nil {
+		return fmt.Errorf("error al conectar con RabbitMQ: %w", err)
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("error al abrir canal: %w", err)
+	}
+
+	if err := ch.Confirm(false); err != nil {
+		ch.Close()
+		conn.Close()
+		return fmt.Errorf("error al activar confirmaciones: %w", err)
+	}
+
+	// El cierre de la conexión también cierra el canal y llega por aquí
+	p.closed = ch.NotifyClose(make(chan *amqp.Error, 1))
+	p.conn = conn
+	p.channel = ch
+	p.declared = make(map[string]bool)
+
+	if err := p.declareQueue(p.queueName); err != nil {
+		p.disconnect()
+		return err
+	}
+
+	return nil
+}
+
+// reconnect reintenta con espera exponencial; devuelve false si el publisher
+// se cierra antes de conseguirlo.
+func (p *eventPublisher) reconnect() bool {
+	delay := p.config.ReconnectDelay
+	for {
+		err := p.connect()
+		if err == nil {
+			log.Printf("Publisher de RabbitMQ reconectado")
+			return true
+		}
+
+		log.Printf("Error al reconectar publisher de RabbitMQ, reintento en %s: %v", delay, err)
+		select {
+		case <-p.aborted.Done():
+			return false
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > p.config.MaxReconnectDelay {
+			delay = p.config.MaxReconnectDelay
+		}
+	}
+}
+
+func (p *eventPublisher) disconnect() {
 	if p.channel != nil {
 		p.channel.Close()
 	}
 	if p.conn != nil {
-		return p.conn.Close()
+		p.conn.Close()
+	}
+	p.channel = nil
+	p.conn = nil
+}
+
+func (p *eventPublisher) declareQueue(queueName string) error {
+	if p.declared[queueName] {
+		return nil
+	}
+
+	if err := declareEventQueue(p.channel, queueName); err != nil {
+		return err
 	}
+
+	p.declared[queueName] = true
 	return nil
 }
 
+// Close deja de aceptar publicaciones y espera a que se confirmen las que
+// están en el buffer. Si ctx vence antes, descarta las pendientes, que fallan
+// con ErrPublisherClosed, y devuelve el error de ctx.
+func (p *eventPublisher) Close(ctx context.Context) error {
+	p.closeOnce.Do(func() { close(p.closing) })
+
+	select {
+	case <-p.stopped:
+		return nil
+	case <-ctx.Done():
+	}
+
+	p.abort()
+	<-p.stopped
+	return fmt.Errorf("publicaciones pendientes descartadas al cerrar: %w", ctx.Err())
+}
+
+func (p *eventPublisher) isClosing() bool {
+	select {
+	case <-p.closing:
+		return true
+	default:
+		return false
+	}
+}
+
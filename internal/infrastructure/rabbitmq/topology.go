@@ -0,0 +1,121 @@
+package rabbitmq
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"user-service/internal/domain"
+)
+
+const (
+	// HeaderAttempts cuenta los intentos fallidos de procesar un mensaje.
+	HeaderAttempts = "x-attempts"
+	// HeaderLastError guarda el último error de un mensaje aparcado.
+	HeaderLastError = "x-last-error"
+)
+
+// ParkedQueue es la cola donde terminan los mensajes que agotaron los
+// reintentos o no se pudieron interpretar. Es el dead-letter de queueName.
+func ParkedQueue(queueName string) string {
+	return queueName + ".parked"
+}
+
+// RetryPolicy reintenta un mensaje fallido tras una espera que se duplica en
+// cada intento, hasta MaxAttempts intentos en total.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// Delay es la espera antes del intento siguiente al fallido número attempts.
+func (p RetryPolicy) Delay(attempts int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempts && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
+
+// RetryQueue nombra la cola de espera por su TTL, así cambiar la política
+// crea colas nuevas en lugar de chocar con los argumentos de las existentes.
+func (p RetryPolicy) RetryQueue(queueName string, attempts int) string {
+	return fmt.Sprintf("%s.retry.%ds", queueName, int(p.Delay(attempts)/time.Second))
+}
+
+// NextQueue decide adónde va un mensaje cuyo handler falló con cause tras
+// attempts fallos previos: la cola de espera del siguiente intento o, si el
+// error es domain.ErrInvalidEvent o se agotaron los intentos, la de aparcados.
+// Devuelve también los fallos acumulados.
+func (p RetryPolicy) NextQueue(queueName string, attempts int, cause error) (string, int) {
+	attempts++
+	if errors.Is(cause, domain.ErrInvalidEvent) || attempts >= p.MaxAttempts {
+		return ParkedQueue(queueName), attempts
+	}
+	return p.RetryQueue(queueName, attempts), attempts
+}
+
+// declareEventQueue declara la cola durable de un evento con su cola de
+// aparcados como dead-letter. Publisher, consumidor y el comando dlq deben
+// declararla con los mismos argumentos.
+func declareEventQueue(ch *amqp.Channel, queueName string) error {
+	if err := declareQueue(ch, ParkedQueue(queueName), nil); err != nil {
+		return err
+	}
+
+	return declareQueue(ch, queueName, amqp.Table{
+		"x-dead-letter-exchange":    "",
+		"x-dead-letter-routing-key": ParkedQueue(queueName),
+	})
+}
+
+// declareRetryQueues declara una cola por cada espera de la política; al
+// vencer el TTL el mensaje vuelve a queueName.
+func declareRetryQueues(ch *amqp.Channel, queueName string, policy RetryPolicy) error {
+	for attempts := 1; attempts < policy.MaxAttempts; attempts++ {
+		err := declareQueue(ch, policy.RetryQueue(queueName, attempts), amqp.Table{
+			"x-message-ttl":             policy.Delay(attempts).Milliseconds(),
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": queueName,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func declareQueue(ch *amqp.Channel, queueName string, args amqp.Table) error {
+	_, err := ch.QueueDeclare(
+		queueName, // nombre
+		true,      // durable
+		false,     // delete when unused
+		false,     // exclusive
+		false,     // no-wait
+		args,      // arguments
+	)
+	if err != nil {
+		return fmt.Errorf("error al declarar cola %s: %w", queueName, err)
+	}
+	return nil
+}
+
+// attemptsFrom lee HeaderAttempts; los mensajes sin cabecera no han fallado.
+func attemptsFrom(headers amqp.Table) int {
+	switch v := headers[HeaderAttempts].(type) {
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case int:
+		return v
+	default:
+		return 0
+	}
+}
+
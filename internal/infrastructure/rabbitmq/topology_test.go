@@ -0,0 +1,99 @@
+package rabbitmq_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/infrastructure/rabbitmq"
+	"user-service/internal/usecase"
+)
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	// Arrange
+	policy := rabbitmq.RetryPolicy{
+		MaxAttempts: 6,
+		BaseDelay:   5 * time.Second,
+		MaxDelay:    30 * time.Second,
+	}
+
+	tests := []struct {
+		attempts int
+		delay    time.Duration
+		queue    string
+	}{
+		{attempts: 1, delay: 5 * time.Second, queue: "user.created.retry.5s"},
+		{attempts: 2, delay: 10 * time.Second, queue: "user.created.retry.10s"},
+		{attempts: 3, delay: 20 * time.Second, queue: "user.created.retry.20s"},
+		{attempts: 4, delay: 30 * time.Second, queue: "user.created.retry.30s"},
+		{attempts: 5, delay: 30 * time.Second, queue: "user.created.retry.30s"},
+	}
+
+	for _, tt := range tests {
+		// Act
+		delay := policy.Delay(tt.attempts)
+		queue := policy.RetryQueue("user.created", tt.attempts)
+
+		// Assert
+		if delay != tt.delay {
+			t.Errorf("Attempt %d: expected delay %v, got %v", tt.attempts, tt.delay, delay)
+		}
+
+		if queue != tt.queue {
+			t.Errorf("Attempt %d: expected queue %s, got %s", tt.attempts, tt.queue, queue)
+		}
+	}
+}
+
+func TestParkedQueue(t *testing.T) {
+	// Act
+	queue := rabbitmq.ParkedQueue("user.created")
+
+	// Assert
+	if queue != "user.created.parked" {
+		t.Errorf("Expected user.created.parked, got %s", queue)
+	}
+}
+
+type failingUserEventRepository struct{}
+
+func (failingUserEventRepository) Create(ctx context.Context, event *domain.UserEvent) error {
+	return errors.New("conexión rechazada")
+}
+
+func TestRetryPolicy_NextQueue(t *testing.T) {
+	// Arrange
+	policy := rabbitmq.RetryPolicy{MaxAttempts: 3, BaseDelay: 5 * time.Second, MaxDelay: 30 * time.Second}
+	recordUserCreated := usecase.NewRecordUserCreatedUseCase(failingUserEventRepository{})
+	payload := &domain.UserCreatedPayload{UserID: "7f1c2d1e-5b0a-4c43-9d2e-3b8f1a6c9e10", Email: "ana@example.com", CreatedAt: time.Now()}
+	persistErr := recordUserCreated.Execute(context.Background(), payload)
+	invalidErr := recordUserCreated.Execute(context.Background(), &domain.UserCreatedPayload{UserID: "u-1"})
+
+	tests := []struct {
+		name     string
+		attempts int
+		cause    error
+		queue    string
+		total    int
+	}{
+		{"failed persist is retried", 0, persistErr, "user.created.retry.5s", 1},
+		{"failed persist backs off", 1, persistErr, "user.created.retry.10s", 2},
+		{"failed persist parks when exhausted", 2, persistErr, "user.created.parked", 3},
+		{"invalid event parks at once", 0, invalidErr, "user.created.parked", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			queue, total := policy.NextQueue("user.created", tt.attempts, tt.cause)
+
+			// Assert
+			if queue != tt.queue || total != tt.total {
+				t.Errorf("Expected %s after %d attempts, got %s after %d", tt.queue, tt.total, queue, total)
+			}
+		})
+	}
+}
+
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"user-service/internal/domain"
+	"gorm.io/gorm"
+)
+
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) domain.APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	if err := r.db.WithContext(ctx).Create(key).Error; err != nil {
+		return fmt.Errorf("error al crear API key: %w", err)
+	}
+	return nil
+}
+
+func (r *apiKeyRepository) FindByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	var key domain.APIKey
+	if err := r.db.WithContext(ctx).Where("key_hash = ?", keyHash).First(&key).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("API key no encontrada: %w", err)
+		}
+		return nil, fmt.Errorf("error al buscar API key: %w", err)
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) FindByID(ctx context.Context, id string) (*domain.APIKey, error) {
+	var key domain.APIKey
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&key).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("API key no encontrada: %w", err)
+		}
+		return nil, fmt.Errorf("error al buscar API key: %w", err)
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) ListByUser(ctx context.Context, userID string) ([]*domain.APIKey, error) {
+	var keys []*domain.APIKey
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Order("created_at DESC").
+		Find(&keys).Error
+	if err != nil {
+		return nil, fmt.Errorf("error al listar API keys: %w", err)
+	}
+	return keys, nil
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, id string) error {
+	err := r.db.WithContext(ctx).
+		Model(&domain.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		return fmt.Errorf("error al revocar API key: %w", err)
+	}
+	return nil
+}
+
+func (r *apiKeyRepository) MarkUsed(ctx context.Context, id string, usedAt time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&domain.APIKey{}).
+		Where("id = ?", id).
+		Update("last_used_at", usedAt).Error
+	if err != nil {
+		return fmt.Errorf("error al registrar uso de API key: %w", err)
+	}
+	return nil
+}
+
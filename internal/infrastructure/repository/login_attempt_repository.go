@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"user-service/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type loginAttemptRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginAttemptRepository(db *gorm.DB) domain.LoginAttemptRepository {
+	return &loginAttemptRepository{db: db}
+}
+
+func (r *loginAttemptRepository) Find(ctx context.Context, key string) (*domain.LoginAttempt, error) {
+	var attempt domain.LoginAttempt
+	if err := r.db.WithContext(ctx).Where("key = ?", key).First(&attempt).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &domain.LoginAttempt{Key: key}, nil
+		}
+		return nil, fmt.Errorf("error al buscar intentos de login: %w", err)
+	}
+	return &attempt, nil
+}
+
+// RecordFailure incrementa el contador en una sola sentencia para que los
+// intentos concurrentes no se pierdan.
+func (r *loginAttemptRepository) RecordFailure(ctx context.Context, key string) (*domain.LoginAttempt, error) {
+	attempt := domain.LoginAttempt{Key: key, Failures: 1, LastFailureAt: time.Now()}
+	err := r.db.WithContext(ctx).
+		Clauses(
+			clause.OnConflict{
+				Columns: []clause.Column{{Name: "key"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"failures":        gorm.Expr("login_attempts.failures + 1"),
+					"last_failure_at": attempt.LastFailureAt,
+				}),
+			},
+			clause.Returning{},
+		).
+		Create(&attempt).Error
+	if err != nil {
+		return nil, fmt.Errorf("error al registrar intento fallido: %w", err)
+	}
+	return &attempt, nil
+}
+
+func (r *loginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&domain.LoginAttempt{}).
+		Where("key = ?", key).
+		Update("locked_until", until).Error
+	if err != nil {
+		return fmt.Errorf("error al bloquear intentos de login: %w", err)
+	}
+	return nil
+}
+
+func (r *loginAttemptRepository) Release(ctx context.Context, key string) error {
+	err := r.db.WithContext(ctx).
+		Model(&domain.LoginAttempt{}).
+		Where("key = ? AND failures > 0", key).
+		Update("failures", gorm.Expr("failures - 1")).Error
+	if err != nil {
+		return fmt.Errorf("error al liberar intento de login: %w", err)
+	}
+	return nil
+}
+
+func (r *loginAttemptRepository) Reset(ctx context.Context, key string) error {
+	if err := r.db.WithContext(ctx).Where("key = ?", key).Delete(&domain.LoginAttempt{}).Error; err != nil {
+		return fmt.Errorf("error al reiniciar intentos de login: %w", err)
+	}
+	return nil
+}
+
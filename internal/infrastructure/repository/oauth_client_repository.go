@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"user-service/internal/domain"
+	"gorm.io/gorm"
+)
+
+type oauthClientRepository struct {
+	db *gorm.DB
+}
+
+func NewOAuthClientRepository(db *gorm.DB) domain.OAuthClientRepository {
+	return &oauthClientRepository{db: db}
+}
+
+func (r *oauthClientRepository) Create(ctx context.Context, client *domain.OAuthClient) error {
+	if err := r.db.WithContext(ctx).Create(client).Error; err != nil {
+		return fmt.Errorf("error al crear cliente OAuth: %w", err)
+	}
+	return nil
+}
+
+func (r *oauthClientRepository) FindByID(ctx context.Context, id string) (*domain.OAuthClient, error) {
+	var client domain.OAuthClient
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&client).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("cliente OAuth no encontrado: %w", err)
+		}
+		return nil, fmt.Errorf("error al buscar cliente OAuth: %w", err)
+	}
+	return &client, nil
+}
+
+func (r *oauthClientRepository) List(ctx context.Context) ([]*domain.OAuthClient, error) {
+	var clients []*domain.OAuthClient
+	if err := r.db.WithContext(ctx).Order("created_at DESC").Find(&clients).Error; err != nil {
+		return nil, fmt.Errorf("error al listar clientes OAuth: %w", err)
+	}
+	return clients, nil
+}
+
+func (r *oauthClientRepository) Disable(ctx context.Context, id string) error {
+	err := r.db.WithContext(ctx).
+		Model(&domain.OAuthClient{}).
+		Where("id = ? AND disabled_at IS NULL", id).
+		Update("disabled_at", time.Now()).Error
+	if err != nil {
+		return fmt.Errorf("error al desactivar cliente OAuth: %w", err)
+	}
+	return nil
+}
+
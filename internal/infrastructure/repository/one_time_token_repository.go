@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"user-service/internal/domain"
+	"gorm.io/gorm"
+)
+
+type oneTimeTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewOneTimeTokenRepository(db *gorm.DB) domain.OneTimeTokenRepository {
+	return &oneTimeTokenRepository{db: db}
+}
+
+func (r *oneTimeTokenRepository) Create(ctx context.Context, token *domain.OneTimeToken) error {
+	if err := r.db.WithContext(ctx).Create(token).Error; err != nil {
+		return fmt.Errorf("error al crear token: %w", err)
+	}
+	return nil
+}
+
+func (r *oneTimeTokenRepository) FindByHash(ctx context.Context, purpose, tokenHash string) (*domain.OneTimeToken, error) {
+	var token domain.OneTimeToken
+	err := r.db.WithContext(ctx).
+		Where("purpose = ? AND token_hash = ?", purpose, tokenHash).
+		First(&token).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("token no encontrado: %w", err)
+		}
+		return nil, fmt.Errorf("error al buscar token: %w", err)
+	}
+	return &token, nil
+}
+
+// MarkUsed consume el token solo si sigue sin usar y vigente; devuelve false si
+// otra petición lo consumió antes.
+func (r *oneTimeTokenRepository) MarkUsed(ctx context.Context, id string) (bool, error) {
+	now := time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&domain.OneTimeToken{}).
+		Where("id = ? AND used_at IS NULL AND expires_at > ?", id, now).
+		Update("used_at", now)
+	if result.Error != nil {
+		return false, fmt.Errorf("error al marcar token como usado: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// InvalidateForUser consume todos los tokens pendientes del usuario para ese propósito.
+func (r *oneTimeTokenRepository) InvalidateForUser(ctx context.Context, userID, purpose string) error {
+	err := r.db.WithContext(ctx).
+		Model(&domain.OneTimeToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", time.Now()).Error
+	if err != nil {
+		return fmt.Errorf("error al invalidar tokens: %w", err)
+	}
+	return nil
+}
+
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"user-service/internal/domain"
+	"gorm.io/gorm"
+)
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) domain.OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+// ClaimPending toma los mensajes más antiguos con FOR UPDATE SKIP LOCKED, así
+// dos relays nunca reclaman el mismo mensaje.
+func (r *outboxRepository) ClaimPending(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*domain.OutboxMessage, error) {
+	var messages []*domain.OutboxMessage
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE outbox SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM outbox
+			WHERE sent_at IS NULL AND next_attempt_at <= ?
+			ORDER BY created_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`, now.Add(lease), now, limit).
+		Scan(&messages).Error
+	if err != nil {
+		return nil, fmt.Errorf("error al reclamar mensajes del outbox: %w", err)
+	}
+	return messages, nil
+}
+
+func (r *outboxRepository) MarkSent(ctx context.Context, id string, sentAt time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&domain.OutboxMessage{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"sent_at":    sentAt,
+			"attempts":   gorm.Expr("attempts + 1"),
+			"last_error": "",
+		}).Error
+	if err != nil {
+		return fmt.Errorf("error al marcar mensaje del outbox como enviado: %w", err)
+	}
+	return nil
+}
+
+func (r *outboxRepository) MarkFailed(ctx context.Context, id string, lastError string, nextAttemptAt time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&domain.OutboxMessage{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"last_error":      lastError,
+			"next_attempt_at": nextAttemptAt,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("error al registrar fallo del outbox: %w", err)
+	}
+	return nil
+}
+
+func (r *outboxRepository) Stats(ctx context.Context, stuckBefore time.Time) (*domain.OutboxStats, error) {
+	var row struct {
+		Pending       int64
+		Stuck         int64
+		OldestPending *time.Time
+	}
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT
+			COUNT(*) AS pending,
+			COUNT(*) FILTER (WHERE created_at < ?) AS stuck,
+			MIN(created_at) AS oldest_pending
+		FROM outbox
+		WHERE sent_at IS NULL`, stuckBefore).
+		Scan(&row).Error
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar estado del outbox: %w", err)
+	}
+	return &domain.OutboxStats{
+		Pending:       row.Pending,
+		Stuck:         row.Stuck,
+		OldestPending: row.OldestPending,
+	}, nil
+}
+
+func (r *outboxRepository) DeleteSentBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("sent_at IS NOT NULL AND sent_at < ?", before).
+		Delete(&domain.OutboxMessage{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("error al purgar mensajes enviados del outbox: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
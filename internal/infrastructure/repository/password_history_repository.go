@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"user-service/internal/domain"
+	"gorm.io/gorm"
+)
+
+type passwordHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordHistoryRepository(db *gorm.DB) domain.PasswordHistoryRepository {
+	return &passwordHistoryRepository{db: db}
+}
+
+func (r *passwordHistoryRepository) Add(ctx context.Context, entry *domain.PasswordHistoryEntry) error {
+	if err := r.db.WithContext(ctx).Create(entry).Error; err != nil {
+		return fmt.Errorf("error al guardar historial de contraseñas: %w", err)
+	}
+	return nil
+}
+
+func (r *passwordHistoryRepository) ListRecent(ctx context.Context, userID string, limit int) ([]*domain.PasswordHistoryEntry, error) {
+	var entries []*domain.PasswordHistoryEntry
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&entries).Error
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener historial de contraseñas: %w", err)
+	}
+	return entries, nil
+}
+
+func (r *passwordHistoryRepository) Prune(ctx context.Context, userID string, keep int) error {
+	recent := r.db.Model(&domain.PasswordHistoryEntry{}).
+		Select("id").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(keep)
+
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND id NOT IN (?)", userID, recent).
+		Delete(&domain.PasswordHistoryEntry{}).Error
+	if err != nil {
+		return fmt.Errorf("error al depurar historial de contraseñas: %w", err)
+	}
+	return nil
+}
+
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"user-service/internal/domain"
+	"gorm.io/gorm"
+)
+
+type recoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewRecoveryCodeRepository(db *gorm.DB) domain.RecoveryCodeRepository {
+	return &recoveryCodeRepository{db: db}
+}
+
+// ReplaceForUser borra los códigos anteriores del usuario y guarda los nuevos
+// en una sola transacción.
+func (r *recoveryCodeRepository) ReplaceForUser(ctx context.Context, userID string, codes []*domain.RecoveryCode) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&domain.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		return tx.Create(&codes).Error
+	})
+	if err != nil {
+		return fmt.Errorf("error al guardar códigos de recuperación: %w", err)
+	}
+	return nil
+}
+
+// Consume marca el código como usado; devuelve false si no existe o ya se usó.
+func (r *recoveryCodeRepository) Consume(ctx context.Context, userID, codeHash string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, fmt.Errorf("error al consumir código de recuperación: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"user-service/internal/domain"
+	"gorm.io/gorm"
+)
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) domain.SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+func (r *sessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	if err := r.db.WithContext(ctx).Create(session).Error; err != nil {
+		return fmt.Errorf("error al crear sesión: %w", err)
+	}
+	return nil
+}
+
+func (r *sessionRepository) FindByID(ctx context.Context, id string) (*domain.Session, error) {
+	var session domain.Session
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&session).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("sesión no encontrada: %w", err)
+		}
+		return nil, fmt.Errorf("error al buscar sesión: %w", err)
+	}
+	return &session, nil
+}
+
+// ListActive descarta las sesiones cuya familia de refresh tokens ya no tiene
+// un token utilizable: así desaparecen también las cerradas por logout,
+// logout-all, cambio de contraseña o reutilización de un refresh token.
+func (r *sessionRepository) ListActive(ctx context.Context, userID string) ([]*domain.Session, error) {
+	live := r.db.Model(&domain.RefreshToken{}).
+		Select("1").
+		Where("refresh_tokens.family_id = sessions.id AND used_at IS NULL AND revoked_at IS NULL AND expires_at > ?", time.Now())
+
+	var sessions []*domain.Session
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND EXISTS (?)", userID, live).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, fmt.Errorf("error al listar sesiones: %w", err)
+	}
+	return sessions, nil
+}
+
+func (r *sessionRepository) Touch(ctx context.Context, id, ipAddress string, seenAt time.Time) error {
+	updates := map[string]interface{}{"last_seen_at": seenAt}
+	if ipAddress != "" {
+		updates["ip_address"] = ipAddress
+	}
+
+	err := r.db.WithContext(ctx).
+		Model(&domain.Session{}).
+		Where("id = ?", id).
+		Updates(updates).Error
+	if err != nil {
+		return fmt.Errorf("error al actualizar sesión: %w", err)
+	}
+	return nil
+}
+
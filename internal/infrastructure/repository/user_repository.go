@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"user-service/internal/domain"
 	"gorm.io/gorm"
@@ -23,6 +24,19 @@ func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
 	return nil
 }
 
+func (r *userRepository) CreateWithOutbox(ctx context.Context, user *domain.User, message *domain.OutboxMessage) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		return tx.Create(message).Error
+	})
+	if err != nil {
+		return fmt.Errorf("error al crear usuario: %w", err)
+	}
+	return nil
+}
+
 func (r *userRepository) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
 	var user domain.User
 	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
@@ -45,3 +59,96 @@ func (r *userRepository) FindByID(ctx context.Context, id string) (*domain.User,
 	return &user, nil
 }
 
+func (r *userRepository) List(ctx context.Context, filter domain.UserFilter) ([]*domain.User, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.User{})
+
+	if filter.Email != "" {
+		query = query.Where(`email ILIKE ? ESCAPE '\'`, containsPattern(filter.Email))
+	}
+	if filter.Name != "" {
+		query = query.Where(`name ILIKE ? ESCAPE '\'`, containsPattern(filter.Name))
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at <= ?", *filter.CreatedTo)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("error al contar usuarios: %w", err)
+	}
+
+	var users []*domain.User
+	err := query.
+		Order("created_at DESC").
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Find(&users).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("error al listar usuarios: %w", err)
+	}
+
+	return users, total, nil
+}
+
+// likeEscaper escapa los comodines de LIKE para que el término se busque literal.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// containsPattern construye un patrón ILIKE que busca term como subcadena.
+func containsPattern(term string) string {
+	return "%" + likeEscaper.Replace(term) + "%"
+}
+
+func (r *userRepository) Update(ctx context.Context, user *domain.User, columns ...string) error {
+	if len(columns) == 0 {
+		return fmt.Errorf("error al actualizar usuario %s: no se indicaron columnas", user.ID)
+	}
+
+	if err := r.db.WithContext(ctx).Model(user).Select(columns).Updates(user).Error; err != nil {
+		return fmt.Errorf("error al actualizar usuario %s: %w", user.ID, err)
+	}
+	return nil
+}
+
+func (r *userRepository) Delete(ctx context.Context, id string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user domain.User
+		if err := tx.Where("id = ?", id).First(&user).Error; err != nil {
+			return err
+		}
+
+		dependents := []interface{}{
+			&domain.Session{},
+			&domain.RefreshToken{},
+			&domain.APIKey{},
+			&domain.PasswordHistoryEntry{},
+			&domain.RecoveryCode{},
+			&domain.OneTimeToken{},
+		}
+		for _, model := range dependents {
+			if err := tx.Where("user_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		attemptKeys := []string{domain.AccountAttemptKey(user.Email), domain.MFAAttemptKey(id)}
+		if err := tx.Where("key IN ?", attemptKeys).Delete(&domain.LoginAttempt{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&user).Error
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return fmt.Errorf("usuario no encontrado con id %s: %w", id, err)
+		}
+		return fmt.Errorf("error al eliminar usuario %s: %w", id, err)
+	}
+	return nil
+}
+
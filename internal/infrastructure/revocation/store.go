@@ -0,0 +1,251 @@
+package revocation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"user-service/internal/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// revocationStore persiste las revocaciones en Postgres y mantiene una caché en
+// memoria para no consultar la base de datos en cada petición autenticada.
+// Las revocaciones hechas por esta instancia se ven de inmediato; las hechas por
+// otras instancias se ven, como mucho, tras cacheTTL.
+type revocationStore struct {
+	db       *gorm.DB
+	cacheTTL time.Duration
+
+	mu        sync.RWMutex
+	tokens    map[string]tokenEntry
+	userLists map[string]userEntry
+	sessions  map[string]sessionEntry
+}
+
+type tokenEntry struct {
+	revoked   bool
+	expiresAt time.Time
+	checkedAt time.Time
+}
+
+type userEntry struct {
+	revokedBefore time.Time
+	checkedAt     time.Time
+}
+
+type sessionEntry struct {
+	revoked   bool
+	checkedAt time.Time
+}
+
+func NewRevocationStore(db *gorm.DB, cacheTTLSeconds int) domain.TokenRevocationStore {
+	return &revocationStore{
+		db:        db,
+		cacheTTL:  time.Duration(cacheTTLSeconds) * time.Second,
+		tokens:    make(map[string]tokenEntry),
+		userLists: make(map[string]userEntry),
+		sessions:  make(map[string]sessionEntry),
+	}
+}
+
+func (s *revocationStore) RevokeToken(ctx context.Context, principal *domain.Principal) error {
+	userID, err := uuid.Parse(principal.UserID)
+	if err != nil {
+		return fmt.Errorf("user_id inválido: %w", err)
+	}
+
+	revoked := &domain.RevokedToken{
+		TokenID:   principal.TokenID,
+		UserID:    userID,
+		ExpiresAt: principal.ExpiresAt,
+	}
+
+	err = s.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(revoked).Error
+	if err != nil {
+		return fmt.Errorf("error al revocar token: %w", err)
+	}
+
+	// Los tokens revocados solo importan hasta su expiración natural.
+	if err := s.db.WithContext(ctx).Where("expires_at < ?", time.Now()).Delete(&domain.RevokedToken{}).Error; err != nil {
+		return fmt.Errorf("error al purgar tokens revocados: %w", err)
+	}
+
+	s.mu.Lock()
+	s.tokens[principal.TokenID] = tokenEntry{revoked: true, expiresAt: principal.ExpiresAt, checkedAt: time.Now()}
+	s.mu.Unlock()
+
+	return nil
+}
+
+// RevokeAllForUser revoca los tokens del usuario emitidos antes de
+// revokedBefore, con el límite de domain.RevocationCutoff.
+func (s *revocationStore) RevokeAllForUser(ctx context.Context, userID string, revokedBefore time.Time) error {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("user_id inválido: %w", err)
+	}
+
+	revokedBefore = domain.RevocationCutoff(revokedBefore)
+
+	revocation := &domain.UserTokenRevocation{
+		UserID:        id,
+		RevokedBefore: revokedBefore,
+	}
+
+	err = s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"revoked_before", "updated_at"}),
+	}).Create(revocation).Error
+	if err != nil {
+		return fmt.Errorf("error al revocar tokens del usuario: %w", err)
+	}
+
+	s.mu.Lock()
+	s.userLists[userID] = userEntry{revokedBefore: revokedBefore, checkedAt: time.Now()}
+	s.mu.Unlock()
+
+	return nil
+}
+
+// RevokeSession marca la sesión como revocada; desde ese momento se rechazan
+// todos los access tokens que la llevan como sid.
+func (s *revocationStore) RevokeSession(ctx context.Context, sessionID string) error {
+	err := s.db.WithContext(ctx).
+		Model(&domain.Session{}).
+		Where("id = ? AND revoked_at IS NULL", sessionID).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		return fmt.Errorf("error al revocar sesión: %w", err)
+	}
+
+	s.mu.Lock()
+	s.sessions[sessionID] = sessionEntry{revoked: true, checkedAt: time.Now()}
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *revocationStore) IsRevoked(ctx context.Context, principal *domain.Principal) (bool, error) {
+	// Los tokens de clientes OAuth2 no tienen usuario.
+	if principal.UserID != "" {
+		revokedBefore, err := s.userRevokedBefore(ctx, principal.UserID)
+		if err != nil {
+			return false, err
+		}
+		if !revokedBefore.IsZero() && !principal.IssuedAt.After(revokedBefore) {
+			return true, nil
+		}
+	}
+
+	// Los tokens restringidos no pertenecen a ninguna sesión.
+	if principal.SessionID != "" {
+		revoked, err := s.sessionRevoked(ctx, principal.SessionID)
+		if err != nil || revoked {
+			return revoked, err
+		}
+	}
+
+	return s.tokenRevoked(ctx, principal)
+}
+
+// sessionRevoked consulta la sesión del token. Las sesiones anteriores a la
+// tabla sessions no tienen registro y se consideran vigentes.
+func (s *revocationStore) sessionRevoked(ctx context.Context, sessionID string) (bool, error) {
+	now := time.Now()
+
+	s.mu.RLock()
+	entry, ok := s.sessions[sessionID]
+	s.mu.RUnlock()
+	if ok && (entry.revoked || now.Sub(entry.checkedAt) < s.cacheTTL) {
+		return entry.revoked, nil
+	}
+
+	var count int64
+	err := s.db.WithContext(ctx).Model(&domain.Session{}).Where("id = ? AND revoked_at IS NOT NULL", sessionID).Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("error al consultar sesiones revocadas: %w", err)
+	}
+
+	s.mu.Lock()
+	s.sessions[sessionID] = sessionEntry{revoked: count > 0, checkedAt: now}
+	s.mu.Unlock()
+
+	return count > 0, nil
+}
+
+func (s *revocationStore) tokenRevoked(ctx context.Context, principal *domain.Principal) (bool, error) {
+	now := time.Now()
+
+	s.mu.RLock()
+	entry, ok := s.tokens[principal.TokenID]
+	s.mu.RUnlock()
+	if ok && (entry.revoked || now.Sub(entry.checkedAt) < s.cacheTTL) {
+		return entry.revoked, nil
+	}
+
+	var count int64
+	err := s.db.WithContext(ctx).Model(&domain.RevokedToken{}).Where("token_id = ?", principal.TokenID).Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("error al consultar tokens revocados: %w", err)
+	}
+
+	s.mu.Lock()
+	s.prune(now)
+	s.tokens[principal.TokenID] = tokenEntry{revoked: count > 0, expiresAt: principal.ExpiresAt, checkedAt: now}
+	s.mu.Unlock()
+
+	return count > 0, nil
+}
+
+func (s *revocationStore) userRevokedBefore(ctx context.Context, userID string) (time.Time, error) {
+	now := time.Now()
+
+	s.mu.RLock()
+	entry, ok := s.userLists[userID]
+	s.mu.RUnlock()
+	if ok && now.Sub(entry.checkedAt) < s.cacheTTL {
+		return entry.revokedBefore, nil
+	}
+
+	var revocation domain.UserTokenRevocation
+	var revokedBefore time.Time
+	err := s.db.WithContext(ctx).Where("user_id = ?", userID).First(&revocation).Error
+	switch {
+	case err == nil:
+		revokedBefore = revocation.RevokedBefore
+	case err != gorm.ErrRecordNotFound:
+		return time.Time{}, fmt.Errorf("error al consultar revocaciones del usuario: %w", err)
+	}
+
+	s.mu.Lock()
+	s.userLists[userID] = userEntry{revokedBefore: revokedBefore, checkedAt: now}
+	s.mu.Unlock()
+
+	return revokedBefore, nil
+}
+
+// prune descarta de la caché los tokens ya expirados y las entradas de usuario
+// y de sesión vencidas; debe llamarse con mu tomado.
+func (s *revocationStore) prune(now time.Time) {
+	for id, entry := range s.tokens {
+		if now.After(entry.expiresAt) {
+			delete(s.tokens, id)
+		}
+	}
+	for id, entry := range s.userLists {
+		if now.Sub(entry.checkedAt) >= s.cacheTTL {
+			delete(s.userLists, id)
+		}
+	}
+	for id, entry := range s.sessions {
+		if now.Sub(entry.checkedAt) >= s.cacheTTL {
+			delete(s.sessions, id)
+		}
+	}
+}
+
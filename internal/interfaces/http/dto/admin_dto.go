@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+type ListUsersQuery struct {
+	Email       string     `form:"email"`
+	Name        string     `form:"name"`
+	Status      string     `form:"status" binding:"omitempty,oneof=active suspended"`
+	CreatedFrom *time.Time `form:"created_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo   *time.Time `form:"created_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Page        int        `form:"page" binding:"omitempty,min=1"`
+	PageSize    int        `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
@@ -0,0 +1,13 @@
+package dto
+
+type CreateOAuthClientRequest struct {
+	Name   string   `json:"name" binding:"required,max=100"`
+	Scopes []string `json:"scopes"`
+}
+
+// OAuthErrorResponse es el formato de error de RFC 6749 §5.2.
+type OAuthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"user-service/internal/domain"
+	"user-service/internal/interfaces/http/dto"
+	"user-service/internal/usecase"
+)
+
+type AdminHandler struct {
+	listUsersUseCase        *usecase.ListUsersUseCase
+	getUserUseCase          *usecase.GetUserUseCase
+	updateUserStatusUseCase *usecase.UpdateUserStatusUseCase
+	deleteUserUseCase       *usecase.DeleteUserUseCase
+	unlockUserUseCase       *usecase.UnlockUserUseCase
+}
+
+func NewAdminHandler(
+	listUsersUseCase *usecase.ListUsersUseCase,
+	getUserUseCase *usecase.GetUserUseCase,
+	updateUserStatusUseCase *usecase.UpdateUserStatusUseCase,
+	deleteUserUseCase *usecase.DeleteUserUseCase,
+	unlockUserUseCase *usecase.UnlockUserUseCase,
+) *AdminHandler {
+	return &AdminHandler{
+		listUsersUseCase:        listUsersUseCase,
+		getUserUseCase:          getUserUseCase,
+		updateUserStatusUseCase: updateUserStatusUseCase,
+		deleteUserUseCase:       deleteUserUseCase,
+		unlockUserUseCase:       unlockUserUseCase,
+	}
+}
+
+// @Summary Listar usuarios
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param email query string false "Filtra por email (contiene)"
+// @Param name query string false "Filtra por nombre (contiene)"
+// @Param status query string false "active | suspended"
+// @Param created_from query string false "RFC3339"
+// @Param created_to query string false "RFC3339"
+// @Param page query int false "Página (desde 1)"
+// @Param page_size query int false "Tamaño de página (máx. 100)"
+// @Success 200 {object} usecase.ListUsersResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/admin/users [get]
+func (h *AdminHandler) ListUsers(c *gin.Context) {
+	var query dto.ListUsersQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "datos inválidos",
+			Message: "Parámetros de búsqueda inválidos: " + err.Error(),
+		})
+		return
+	}
+
+	useCaseReq := usecase.ListUsersRequest{
+		Email:       query.Email,
+		Name:        query.Name,
+		Status:      query.Status,
+		CreatedFrom: query.CreatedFrom,
+		CreatedTo:   query.CreatedTo,
+		Page:        query.Page,
+		PageSize:    query.PageSize,
+	}
+
+	response, err := h.listUsersUseCase.Execute(c.Request.Context(), useCaseReq)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Obtener usuario por ID
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "ID del usuario"
+// @Success 200 {object} usecase.GetUserResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/admin/users/{id} [get]
+func (h *AdminHandler) GetUser(c *gin.Context) {
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.getUserUseCase.Execute(c.Request.Context(), userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Suspender usuario
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "ID del usuario"
+// @Success 200 {object} usecase.GetUserResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/admin/users/{id}/suspend [post]
+func (h *AdminHandler) SuspendUser(c *gin.Context) {
+	h.updateStatus(c, domain.UserStatusSuspended)
+}
+
+// @Summary Reactivar usuario
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "ID del usuario"
+// @Success 200 {object} usecase.GetUserResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/admin/users/{id}/reactivate [post]
+func (h *AdminHandler) ReactivateUser(c *gin.Context) {
+	h.updateStatus(c, domain.UserStatusActive)
+}
+
+// @Summary Desbloquear usuario
+// @Description Levanta el bloqueo temporal por logins fallidos
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "ID del usuario"
+// @Success 200 {object} usecase.GetUserResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/admin/users/{id}/unlock [post]
+func (h *AdminHandler) UnlockUser(c *gin.Context) {
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.unlockUserUseCase.Execute(c.Request.Context(), userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *AdminHandler) updateStatus(c *gin.Context, status string) {
+	principal, ok := currentPrincipal(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	useCaseReq := usecase.UpdateUserStatusRequest{
+		ActorID: principal.UserID,
+		UserID:  userID,
+		Status:  status,
+	}
+
+	response, err := h.updateUserStatusUseCase.Execute(c.Request.Context(), useCaseReq)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Eliminar usuario
+// @Tags admin
+// @Security BearerAuth
+// @Param id path string true "ID del usuario"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/admin/users/{id} [delete]
+func (h *AdminHandler) DeleteUser(c *gin.Context) {
+	principal, ok := currentPrincipal(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	useCaseReq := usecase.DeleteUserRequest{
+		ActorID: principal.UserID,
+		UserID:  userID,
+	}
+
+	if err := h.deleteUserUseCase.Execute(c.Request.Context(), useCaseReq); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// userIDParam valida que el parámetro :id sea un UUID y responde 400 si no lo es.
+func userIDParam(c *gin.Context) (string, bool) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "datos inválidos",
+			Message: "El id de usuario debe ser un UUID",
+		})
+		return "", false
+	}
+	return id, true
+}
+
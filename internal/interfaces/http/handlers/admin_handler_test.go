@@ -0,0 +1,65 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"user-service/internal/interfaces/http/handlers"
+	"user-service/internal/usecase"
+)
+
+func setupAdminRouter(handler *handlers.AdminHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	admin := router.Group("/api/v1/admin")
+	{
+		admin.GET("/users", handler.ListUsers)
+		admin.GET("/users/:id", handler.GetUser)
+	}
+	return router
+}
+
+func newAdminHandler() *handlers.AdminHandler {
+	return handlers.NewAdminHandler(
+		&usecase.ListUsersUseCase{},
+		&usecase.GetUserUseCase{},
+		&usecase.UpdateUserStatusUseCase{},
+		&usecase.DeleteUserUseCase{},
+		&usecase.UnlockUserUseCase{},
+	)
+}
+
+func TestAdminHandler_GetUser_InvalidID(t *testing.T) {
+	// Arrange
+	router := setupAdminRouter(newAdminHandler())
+
+	req, _ := http.NewRequest("GET", "/api/v1/admin/users/not-a-uuid", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %d", w.Code)
+	}
+}
+
+func TestAdminHandler_ListUsers_InvalidQuery(t *testing.T) {
+	// Arrange
+	router := setupAdminRouter(newAdminHandler())
+
+	req, _ := http.NewRequest("GET", "/api/v1/admin/users?status=deleted&created_from=yesterday", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %d", w.Code)
+	}
+}
+
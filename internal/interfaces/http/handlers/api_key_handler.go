@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"user-service/internal/interfaces/http/dto"
+	"user-service/internal/usecase"
+)
+
+// APIKeyHandler atiende las API keys del propio usuario (/users/me/api-keys)
+// y, desde administración, las de cualquier usuario (/admin/users/:id/api-keys).
+type APIKeyHandler struct {
+	createAPIKeyUseCase *usecase.CreateAPIKeyUseCase
+	listAPIKeysUseCase  *usecase.ListAPIKeysUseCase
+	revokeAPIKeyUseCase *usecase.RevokeAPIKeyUseCase
+}
+
+func NewAPIKeyHandler(
+	createAPIKeyUseCase *usecase.CreateAPIKeyUseCase,
+	listAPIKeysUseCase *usecase.ListAPIKeysUseCase,
+	revokeAPIKeyUseCase *usecase.RevokeAPIKeyUseCase,
+) *APIKeyHandler {
+	return &APIKeyHandler{
+		createAPIKeyUseCase: createAPIKeyUseCase,
+		listAPIKeysUseCase:  listAPIKeysUseCase,
+		revokeAPIKeyUseCase: revokeAPIKeyUseCase,
+	}
+}
+
+// @Summary Crear API key
+// @Description La key en claro solo se devuelve en esta respuesta
+// @Tags users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateAPIKeyRequest true "Nombre, scopes y expiración"
+// @Success 201 {object} usecase.CreateAPIKeyResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/users/me/api-keys [post]
+func (h *APIKeyHandler) CreateOwn(c *gin.Context) {
+	principal, ok := currentPrincipal(c)
+	if !ok {
+		return
+	}
+
+	h.create(c, principal.UserID)
+}
+
+// @Summary Listar API keys
+// @Tags users
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} usecase.ListAPIKeysResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/users/me/api-keys [get]
+func (h *APIKeyHandler) ListOwn(c *gin.Context) {
+	principal, ok := currentPrincipal(c)
+	if !ok {
+		return
+	}
+
+	h.list(c, principal.UserID)
+}
+
+// @Summary Revocar API key
+// @Tags users
+// @Security BearerAuth
+// @Param id path string true "ID de la API key"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/users/me/api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeOwn(c *gin.Context) {
+	principal, ok := currentPrincipal(c)
+	if !ok {
+		return
+	}
+
+	h.revoke(c, principal.UserID, c.Param("id"))
+}
+
+// @Summary Crear API key de un usuario
+// @Description Para cuentas de servicio; la key en claro solo se devuelve en esta respuesta
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "ID del usuario"
+// @Param request body dto.CreateAPIKeyRequest true "Nombre, scopes y expiración"
+// @Success 201 {object} usecase.CreateAPIKeyResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/admin/users/{id}/api-keys [post]
+func (h *APIKeyHandler) CreateForUser(c *gin.Context) {
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	h.create(c, userID)
+}
+
+// @Summary Listar API keys de un usuario
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "ID del usuario"
+// @Success 200 {object} usecase.ListAPIKeysResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/admin/users/{id}/api-keys [get]
+func (h *APIKeyHandler) ListForUser(c *gin.Context) {
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	h.list(c, userID)
+}
+
+// @Summary Revocar API key de un usuario
+// @Tags admin
+// @Security BearerAuth
+// @Param id path string true "ID del usuario"
+// @Param key_id path string true "ID de la API key"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/admin/users/{id}/api-keys/{key_id} [delete]
+func (h *APIKeyHandler) RevokeForUser(c *gin.Context) {
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	h.revoke(c, userID, c.Param("key_id"))
+}
+
+func (h *APIKeyHandler) create(c *gin.Context, userID string) {
+	var req dto.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "datos inválidos",
+			Message: "El nombre es requerido y la expiración, si se indica, debe ser de al menos un día: " + err.Error(),
+		})
+		return
+	}
+
+	useCaseReq := usecase.CreateAPIKeyRequest{
+		UserID:        userID,
+		Name:          req.Name,
+		Scopes:        req.Scopes,
+		ExpiresInDays: req.ExpiresInDays,
+	}
+
+	response, err := h.createAPIKeyUseCase.Execute(c.Request.Context(), useCaseReq)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+func (h *APIKeyHandler) list(c *gin.Context, userID string) {
+	response, err := h.listAPIKeysUseCase.Execute(c.Request.Context(), userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *APIKeyHandler) revoke(c *gin.Context, userID, apiKeyID string) {
+	if _, err := uuid.Parse(apiKeyID); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "datos inválidos",
+			Message: "El id de la API key debe ser un UUID",
+		})
+		return
+	}
+
+	useCaseReq := usecase.RevokeAPIKeyRequest{
+		UserID:   userID,
+		APIKeyID: apiKeyID,
+	}
+
+	if err := h.revokeAPIKeyUseCase.Execute(c.Request.Context(), useCaseReq); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
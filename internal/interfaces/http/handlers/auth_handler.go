@@ -0,0 +1,293 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"user-service/internal/interfaces/http/dto"
+	"user-service/internal/usecase"
+)
+
+type AuthHandler struct {
+	refreshTokenUseCase       *usecase.RefreshTokenUseCase
+	logoutUseCase             *usecase.LogoutUseCase
+	logoutAllUseCase          *usecase.LogoutAllUseCase
+	forgotPasswordUseCase     *usecase.ForgotPasswordUseCase
+	resetPasswordUseCase      *usecase.ResetPasswordUseCase
+	verifyEmailUseCase        *usecase.VerifyEmailUseCase
+	resendVerificationUseCase *usecase.ResendVerificationUseCase
+	verifyMFAUseCase          *usecase.VerifyMFAUseCase
+}
+
+func NewAuthHandler(
+	refreshTokenUseCase *usecase.RefreshTokenUseCase,
+	logoutUseCase *usecase.LogoutUseCase,
+	logoutAllUseCase *usecase.LogoutAllUseCase,
+	forgotPasswordUseCase *usecase.ForgotPasswordUseCase,
+	resetPasswordUseCase *usecase.ResetPasswordUseCase,
+	verifyEmailUseCase *usecase.VerifyEmailUseCase,
+	resendVerificationUseCase *usecase.ResendVerificationUseCase,
+	verifyMFAUseCase *usecase.VerifyMFAUseCase,
+) *AuthHandler {
+	return &AuthHandler{
+		refreshTokenUseCase:       refreshTokenUseCase,
+		logoutUseCase:             logoutUseCase,
+		logoutAllUseCase:          logoutAllUseCase,
+		forgotPasswordUseCase:     forgotPasswordUseCase,
+		resetPasswordUseCase:      resetPasswordUseCase,
+		verifyEmailUseCase:        verifyEmailUseCase,
+		resendVerificationUseCase: resendVerificationUseCase,
+		verifyMFAUseCase:          verifyMFAUseCase,
+	}
+}
+
+// @Summary Renovar tokens
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} usecase.RefreshTokenResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/auth/refresh [post]
+func (h *AuthHandler) RefreshToken(c *gin.Context) {
+	var req dto.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "datos inválidos",
+			Message: "El refresh token es requerido: " + err.Error(),
+		})
+		return
+	}
+
+	useCaseReq := usecase.RefreshTokenRequest{
+		RefreshToken: req.RefreshToken,
+		IPAddress:    c.ClientIP(),
+	}
+
+	response, err := h.refreshTokenUseCase.Execute(c.Request.Context(), useCaseReq)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Cerrar sesión actual
+// @Tags auth
+// @Security BearerAuth
+// @Accept json
+// @Param Authorization header string true "Bearer {token}"
+// @Param request body dto.LogoutRequest false "Refresh token de la sesión"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	principal, ok := currentPrincipal(c)
+	if !ok {
+		return
+	}
+
+	var req dto.LogoutRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "datos inválidos",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	useCaseReq := usecase.LogoutRequest{
+		Principal:    principal,
+		RefreshToken: req.RefreshToken,
+	}
+
+	if err := h.logoutUseCase.Execute(c.Request.Context(), useCaseReq); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Cerrar todas las sesiones
+// @Tags auth
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Success 204
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/auth/logout-all [post]
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	principal, ok := currentPrincipal(c)
+	if !ok {
+		return
+	}
+
+	if err := h.logoutAllUseCase.Execute(c.Request.Context(), principal.UserID); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Solicitar restablecimiento de contraseña
+// @Description Responde 202 exista o no el email, para no revelar qué cuentas existen
+// @Tags auth
+// @Accept json
+// @Param request body dto.ForgotPasswordRequest true "Email de la cuenta"
+// @Success 202
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /api/v1/auth/password/forgot [post]
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req dto.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "datos inválidos",
+			Message: "El email es requerido: " + err.Error(),
+		})
+		return
+	}
+
+	useCaseReq := usecase.ForgotPasswordRequest{
+		Email: req.Email,
+	}
+
+	if err := h.forgotPasswordUseCase.Execute(c.Request.Context(), useCaseReq); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// @Summary Restablecer contraseña
+// @Tags auth
+// @Accept json
+// @Param request body dto.ResetPasswordRequest true "Token recibido y nueva contraseña"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /api/v1/auth/password/reset [post]
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req dto.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "datos inválidos",
+			Message: "El token y la nueva contraseña son requeridos: " + err.Error(),
+		})
+		return
+	}
+
+	useCaseReq := usecase.ResetPasswordRequest{
+		Token:       req.Token,
+		NewPassword: req.NewPassword,
+	}
+
+	if err := h.resetPasswordUseCase.Execute(c.Request.Context(), useCaseReq); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Verificar email
+// @Tags auth
+// @Accept json
+// @Param request body dto.VerifyEmailRequest true "Token recibido por email"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /api/v1/auth/verify-email [post]
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req dto.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "datos inválidos",
+			Message: "El token es requerido: " + err.Error(),
+		})
+		return
+	}
+
+	useCaseReq := usecase.VerifyEmailRequest{
+		Token: req.Token,
+	}
+
+	if err := h.verifyEmailUseCase.Execute(c.Request.Context(), useCaseReq); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Reenviar email de verificación
+// @Description Responde 202 exista o no el email, para no revelar qué cuentas existen
+// @Tags auth
+// @Accept json
+// @Param request body dto.ResendVerificationRequest true "Email de la cuenta"
+// @Success 202
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /api/v1/auth/verify-email/resend [post]
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	var req dto.ResendVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "datos inválidos",
+			Message: "El email es requerido: " + err.Error(),
+		})
+		return
+	}
+
+	useCaseReq := usecase.ResendVerificationRequest{
+		Email: req.Email,
+	}
+
+	if err := h.resendVerificationUseCase.Execute(c.Request.Context(), useCaseReq); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// @Summary Completar login con MFA
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.VerifyMFARequest true "Token del desafío y código TOTP o de recuperación"
+// @Success 200 {object} usecase.LoginResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
+// @Router /api/v1/auth/mfa/verify [post]
+func (h *AuthHandler) VerifyMFA(c *gin.Context) {
+	var req dto.VerifyMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "datos inválidos",
+			Message: "Se requiere mfa_token y un código TOTP o de recuperación: " + err.Error(),
+		})
+		return
+	}
+
+	useCaseReq := usecase.VerifyMFARequest{
+		MFAToken:     req.MFAToken,
+		Code:         req.Code,
+		RecoveryCode: req.RecoveryCode,
+		IPAddress:    c.ClientIP(),
+		UserAgent:    c.Request.UserAgent(),
+	}
+
+	response, err := h.verifyMFAUseCase.Execute(c.Request.Context(), useCaseReq)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
@@ -0,0 +1,79 @@
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"user-service/internal/interfaces/http/handlers"
+	"user-service/internal/usecase"
+)
+
+func setupAuthRouter(handler *handlers.AuthHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	api := router.Group("/api/v1")
+	{
+		api.POST("/auth/refresh", handler.RefreshToken)
+		api.POST("/auth/logout", handler.Logout)
+	}
+	return router
+}
+
+func TestAuthHandler_RefreshToken_MissingToken(t *testing.T) {
+	// Arrange
+	handler := handlers.NewAuthHandler(
+		&usecase.RefreshTokenUseCase{},
+		&usecase.LogoutUseCase{},
+		&usecase.LogoutAllUseCase{},
+		&usecase.ForgotPasswordUseCase{},
+		&usecase.ResetPasswordUseCase{},
+		&usecase.VerifyEmailUseCase{},
+		&usecase.ResendVerificationUseCase{},
+		&usecase.VerifyMFAUseCase{},
+	)
+
+	router := setupAuthRouter(handler)
+
+	req, _ := http.NewRequest("POST", "/api/v1/auth/refresh", bytes.NewBufferString("{}"))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %d", w.Code)
+	}
+}
+
+func TestAuthHandler_Logout_WithoutClaims(t *testing.T) {
+	// Arrange
+	handler := handlers.NewAuthHandler(
+		&usecase.RefreshTokenUseCase{},
+		&usecase.LogoutUseCase{},
+		&usecase.LogoutAllUseCase{},
+		&usecase.ForgotPasswordUseCase{},
+		&usecase.ResetPasswordUseCase{},
+		&usecase.VerifyEmailUseCase{},
+		&usecase.ResendVerificationUseCase{},
+		&usecase.VerifyMFAUseCase{},
+	)
+
+	router := setupAuthRouter(handler)
+
+	req, _ := http.NewRequest("POST", "/api/v1/auth/logout", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status code 401, got %d", w.Code)
+	}
+}
+
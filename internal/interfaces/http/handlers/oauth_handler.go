@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"user-service/internal/interfaces/http/dto"
+	"user-service/internal/usecase"
+	"user-service/pkg/errors"
+)
+
+type OAuthHandler struct {
+	clientCredentialsUseCase  *usecase.ClientCredentialsUseCase
+	createOAuthClientUseCase  *usecase.CreateOAuthClientUseCase
+	listOAuthClientsUseCase   *usecase.ListOAuthClientsUseCase
+	disableOAuthClientUseCase *usecase.DisableOAuthClientUseCase
+}
+
+func NewOAuthHandler(
+	clientCredentialsUseCase *usecase.ClientCredentialsUseCase,
+	createOAuthClientUseCase *usecase.CreateOAuthClientUseCase,
+	listOAuthClientsUseCase *usecase.ListOAuthClientsUseCase,
+	disableOAuthClientUseCase *usecase.DisableOAuthClientUseCase,
+) *OAuthHandler {
+	return &OAuthHandler{
+		clientCredentialsUseCase:  clientCredentialsUseCase,
+		createOAuthClientUseCase:  createOAuthClientUseCase,
+		listOAuthClientsUseCase:   listOAuthClientsUseCase,
+		disableOAuthClientUseCase: disableOAuthClientUseCase,
+	}
+}
+
+// @Summary Token OAuth2 (client_credentials)
+// @Description RFC 6749 §4.4. El cliente se autentica con HTTP Basic o con client_id y client_secret en el formulario
+// @Tags oauth
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param grant_type formData string true "client_credentials"
+// @Param scope formData string false "Scopes separados por espacios"
+// @Param client_id formData string false "Si no se usa HTTP Basic"
+// @Param client_secret formData string false "Si no se usa HTTP Basic"
+// @Success 200 {object} usecase.ClientCredentialsResponse
+// @Failure 400 {object} dto.OAuthErrorResponse
+// @Failure 401 {object} dto.OAuthErrorResponse
+// @Router /oauth/token [post]
+func (h *OAuthHandler) Token(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+
+	clientID, clientSecret, basic := c.Request.BasicAuth()
+	if basic {
+		// RFC 6749 §2.3.1: en Basic las credenciales van codificadas como formulario.
+		clientID, _ = url.QueryUnescape(clientID)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+		if c.PostForm("client_secret") != "" {
+			oauthError(c, http.StatusBadRequest, "invalid_request", "Use un solo método de autenticación de cliente")
+			return
+		}
+	} else {
+		clientID = c.PostForm("client_id")
+		clientSecret = c.PostForm("client_secret")
+	}
+
+	grantType := c.PostForm("grant_type")
+	if grantType == "" {
+		oauthError(c, http.StatusBadRequest, "invalid_request", "grant_type es requerido")
+		return
+	}
+
+	useCaseReq := usecase.ClientCredentialsRequest{
+		GrantType:    grantType,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Scope:        c.PostForm("scope"),
+	}
+
+	response, err := h.clientCredentialsUseCase.Execute(c.Request.Context(), useCaseReq)
+	if err != nil {
+		handleOAuthError(c, err, basic)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Registrar cliente OAuth2
+// @Description El secreto solo se devuelve en esta respuesta
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateOAuthClientRequest true "Nombre y scopes permitidos"
+// @Success 201 {object} usecase.CreateOAuthClientResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/admin/oauth-clients [post]
+func (h *OAuthHandler) CreateClient(c *gin.Context) {
+	var req dto.CreateOAuthClientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "datos inválidos",
+			Message: "El nombre del cliente es requerido: " + err.Error(),
+		})
+		return
+	}
+
+	useCaseReq := usecase.CreateOAuthClientRequest{
+		Name:   req.Name,
+		Scopes: req.Scopes,
+	}
+
+	response, err := h.createOAuthClientUseCase.Execute(c.Request.Context(), useCaseReq)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// @Summary Listar clientes OAuth2
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} usecase.ListOAuthClientsResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/admin/oauth-clients [get]
+func (h *OAuthHandler) ListClients(c *gin.Context) {
+	response, err := h.listOAuthClientsUseCase.Execute(c.Request.Context())
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Desactivar cliente OAuth2
+// @Description El cliente deja de obtener tokens; los emitidos valen hasta expirar
+// @Tags admin
+// @Security BearerAuth
+// @Param client_id path string true "client_id"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/admin/oauth-clients/{client_id} [delete]
+func (h *OAuthHandler) DisableClient(c *gin.Context) {
+	clientID := c.Param("client_id")
+	if _, err := uuid.Parse(clientID); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "datos inválidos",
+			Message: "El client_id debe ser un UUID",
+		})
+		return
+	}
+
+	if err := h.disableOAuthClientUseCase.Execute(c.Request.Context(), clientID); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// handleOAuthError traduce los errores del grant a los códigos de RFC 6749
+// §5.2. Si el cliente se autenticó con Basic, invalid_client lleva el desafío
+// WWW-Authenticate.
+func handleOAuthError(c *gin.Context, err error, basic bool) {
+	errWithCode, ok := err.(*errors.ErrorWithCode)
+	if !ok {
+		oauthError(c, http.StatusInternalServerError, "server_error", "")
+		return
+	}
+
+	switch errWithCode.Err {
+	case errors.ErrInvalidClient:
+		if basic {
+			c.Header("WWW-Authenticate", `Basic realm="oauth"`)
+		}
+		oauthError(c, http.StatusUnauthorized, "invalid_client", errWithCode.Message)
+	case errors.ErrInvalidScope:
+		oauthError(c, http.StatusBadRequest, "invalid_scope", errWithCode.Message)
+	case errors.ErrUnsupportedGrantType:
+		oauthError(c, http.StatusBadRequest, "unsupported_grant_type", errWithCode.Message)
+	default:
+		oauthError(c, http.StatusInternalServerError, "server_error", "")
+	}
+}
+
+func oauthError(c *gin.Context, status int, code, description string) {
+	c.JSON(status, dto.OAuthErrorResponse{
+		Error:            code,
+		ErrorDescription: description,
+	})
+}
+
@@ -4,26 +4,47 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"user-service/internal/domain"
 	"user-service/internal/interfaces/http/dto"
+	"user-service/internal/interfaces/http/middleware"
 	"user-service/internal/usecase"
 	"user-service/pkg/errors"
 )
 
 type UserHandler struct {
-	createUserUseCase *usecase.CreateUserUseCase
-	loginUseCase      *usecase.LoginUseCase
-	getUserUseCase    *usecase.GetUserUseCase
+	createUserUseCase     *usecase.CreateUserUseCase
+	loginUseCase          *usecase.LoginUseCase
+	getUserUseCase        *usecase.GetUserUseCase
+	updateProfileUseCase  *usecase.UpdateProfileUseCase
+	changePasswordUseCase *usecase.ChangePasswordUseCase
+	enrollTOTPUseCase     *usecase.EnrollTOTPUseCase
+	confirmTOTPUseCase    *usecase.ConfirmTOTPUseCase
+	listSessionsUseCase   *usecase.ListSessionsUseCase
+	revokeSessionUseCase  *usecase.RevokeSessionUseCase
 }
 
 func NewUserHandler(
 	createUserUseCase *usecase.CreateUserUseCase,
 	loginUseCase *usecase.LoginUseCase,
 	getUserUseCase *usecase.GetUserUseCase,
+	updateProfileUseCase *usecase.UpdateProfileUseCase,
+	changePasswordUseCase *usecase.ChangePasswordUseCase,
+	enrollTOTPUseCase *usecase.EnrollTOTPUseCase,
+	confirmTOTPUseCase *usecase.ConfirmTOTPUseCase,
+	listSessionsUseCase *usecase.ListSessionsUseCase,
+	revokeSessionUseCase *usecase.RevokeSessionUseCase,
 ) *UserHandler {
 	return &UserHandler{
-		createUserUseCase: createUserUseCase,
-		loginUseCase:     loginUseCase,
-		getUserUseCase:   getUserUseCase,
+		createUserUseCase:     createUserUseCase,
+		loginUseCase:          loginUseCase,
+		getUserUseCase:        getUserUseCase,
+		updateProfileUseCase:  updateProfileUseCase,
+		changePasswordUseCase: changePasswordUseCase,
+		enrollTOTPUseCase:     enrollTOTPUseCase,
+		confirmTOTPUseCase:    confirmTOTPUseCase,
+		listSessionsUseCase:   listSessionsUseCase,
+		revokeSessionUseCase:  revokeSessionUseCase,
 	}
 }
 
@@ -33,6 +54,7 @@ func NewUserHandler(
 // @Produce json
 // @Param request body dto.CreateUserRequest true "Datos del usuario"
 // @Success 201 {object} usecase.CreateUserResponse
+// @Success 202 {object} usecase.CreateUserResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 403 {object} dto.ErrorResponse
 // @Failure 409 {object} dto.ErrorResponse
@@ -49,9 +71,11 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 	}
 
 	useCaseReq := usecase.CreateUserRequest{
-		Email:    req.Email,
-		Password: req.Password,
-		Name:     req.Name,
+		Email:     req.Email,
+		Password:  req.Password,
+		Name:      req.Name,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
 	}
 
 	response, err := h.createUserUseCase.Execute(c.Request.Context(), useCaseReq)
@@ -60,6 +84,13 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 		return
 	}
 
+	// Sin usuario en la respuesta el alta queda pendiente de confirmar por email
+	// y no se revela si la cuenta ya existía.
+	if response.User == nil {
+		c.JSON(http.StatusAccepted, response)
+		return
+	}
+
 	c.JSON(http.StatusCreated, response)
 }
 
@@ -68,7 +99,7 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param request body dto.LoginRequest true "Credenciales"
-// @Success 200 {object} usecase.LoginResponse
+// @Success 200 {object} usecase.LoginResponse "Tokens, o mfa_token si el usuario tiene MFA"
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Router /api/v1/auth/login [post]
@@ -83,8 +114,10 @@ func (h *UserHandler) Login(c *gin.Context) {
 	}
 
 	useCaseReq := usecase.LoginRequest{
-		Email:    req.Email,
-		Password: req.Password,
+		Email:     req.Email,
+		Password:  req.Password,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
 	}
 
 	response, err := h.loginUseCase.Execute(c.Request.Context(), useCaseReq)
@@ -106,23 +139,165 @@ func (h *UserHandler) Login(c *gin.Context) {
 // @Failure 404 {object} dto.ErrorResponse
 // @Router /api/v1/users/me [get]
 func (h *UserHandler) GetUser(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error: "no autorizado",
+	principal, ok := currentPrincipal(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.getUserUseCase.Execute(c.Request.Context(), principal.UserID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Actualizar perfil
+// @Tags users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param request body dto.UpdateProfileRequest true "Campos a actualizar"
+// @Success 200 {object} usecase.GetUserResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/users/me [patch]
+func (h *UserHandler) UpdateProfile(c *gin.Context) {
+	principal, ok := currentPrincipal(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "datos inválidos",
+			Message: "Verifique que los campos enviados sean válidos: " + err.Error(),
 		})
 		return
 	}
 
-	userIDStr, ok := userID.(string)
+	useCaseReq := usecase.UpdateProfileRequest{
+		UserID: principal.UserID,
+		Name:   req.Name,
+	}
+
+	response, err := h.updateProfileUseCase.Execute(c.Request.Context(), useCaseReq)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Cambiar contraseña
+// @Description Revoca el resto de sesiones y devuelve un access token nuevo para la sesión actual
+// @Tags users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param request body dto.ChangePasswordRequest true "Contraseña actual y nueva"
+// @Success 200 {object} usecase.ChangePasswordResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/users/me/password [post]
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	principal, ok := currentPrincipal(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "datos inválidos",
+			Message: "La contraseña actual y la nueva son requeridas: " + err.Error(),
+		})
+		return
+	}
+
+	useCaseReq := usecase.ChangePasswordRequest{
+		Principal:       principal,
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+		IPAddress:       c.ClientIP(),
+		UserAgent:       c.Request.UserAgent(),
+	}
+
+	response, err := h.changePasswordUseCase.Execute(c.Request.Context(), useCaseReq)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Enrolar TOTP
+// @Description Genera un secreto TOTP y su URI de aprovisionamiento; MFA se activa al confirmar un código
+// @Tags users
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Success 200 {object} usecase.EnrollTOTPResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /api/v1/users/me/mfa/totp [post]
+func (h *UserHandler) EnrollTOTP(c *gin.Context) {
+	principal, ok := currentPrincipal(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.enrollTOTPUseCase.Execute(c.Request.Context(), principal.UserID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Confirmar TOTP
+// @Description Activa MFA y devuelve los códigos de recuperación, que solo se muestran esta vez
+// @Tags users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Param request body dto.ConfirmTOTPRequest true "Código de la app autenticadora"
+// @Success 200 {object} usecase.ConfirmTOTPResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /api/v1/users/me/mfa/totp/confirm [post]
+func (h *UserHandler) ConfirmTOTP(c *gin.Context) {
+	principal, ok := currentPrincipal(c)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error: "error interno",
+		return
+	}
+
+	var req dto.ConfirmTOTPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "datos inválidos",
+			Message: "El código de 6 dígitos es requerido: " + err.Error(),
 		})
 		return
 	}
 
-	response, err := h.getUserUseCase.Execute(c.Request.Context(), userIDStr)
+	useCaseReq := usecase.ConfirmTOTPRequest{
+		UserID: principal.UserID,
+		Code:   req.Code,
+	}
+
+	response, err := h.confirmTOTPUseCase.Execute(c.Request.Context(), useCaseReq)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -131,11 +306,88 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// @Summary Listar sesiones
+// @Description Sesiones abiertas del usuario; current marca la de la petición
+// @Tags users
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer {token}"
+// @Success 200 {object} usecase.ListSessionsResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/users/me/sessions [get]
+func (h *UserHandler) ListSessions(c *gin.Context) {
+	principal, ok := currentPrincipal(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.listSessionsUseCase.Execute(c.Request.Context(), principal)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Cerrar sesión
+// @Description Revoca los tokens de una sesión del usuario, de este u otro dispositivo
+// @Tags users
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path string true "ID de la sesión"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/users/me/sessions/{id} [delete]
+func (h *UserHandler) RevokeSession(c *gin.Context) {
+	principal, ok := currentPrincipal(c)
+	if !ok {
+		return
+	}
+
+	sessionID := c.Param("id")
+	if _, err := uuid.Parse(sessionID); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "datos inválidos",
+			Message: "El id de sesión debe ser un UUID",
+		})
+		return
+	}
+
+	useCaseReq := usecase.RevokeSessionRequest{
+		Principal: principal,
+		SessionID: sessionID,
+	}
+
+	if err := h.revokeSessionUseCase.Execute(c.Request.Context(), useCaseReq); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// currentPrincipal obtiene el principal que AuthMiddleware deja en el contexto
+// y responde 401 si no está.
+func currentPrincipal(c *gin.Context) (*domain.Principal, bool) {
+	principal, ok := middleware.CurrentPrincipal(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error: "no autorizado",
+		})
+		return nil, false
+	}
+	return principal, true
+}
+
 func handleError(c *gin.Context, err error) {
 	if errWithCode, ok := err.(*errors.ErrorWithCode); ok {
 		c.JSON(errWithCode.Code, dto.ErrorResponse{
 			Error:   errWithCode.Message,
 			Message: errWithCode.Error(),
+			Details: errWithCode.Details,
 		})
 		return
 	}
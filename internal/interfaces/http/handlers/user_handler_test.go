@@ -51,6 +51,7 @@ func setupRouter(handler *handlers.UserHandler) *gin.Engine {
 		api.POST("/users", handler.CreateUser)
 		api.POST("/auth/login", handler.Login)
 		api.GET("/users/me", handler.GetUser)
+		api.PATCH("/users/me", handler.UpdateProfile)
 	}
 	return router
 }
@@ -62,6 +63,12 @@ func TestUserHandler_CreateUser_Success(t *testing.T) {
 		&usecase.CreateUserUseCase{},
 		&usecase.LoginUseCase{},
 		&usecase.GetUserUseCase{},
+		&usecase.UpdateProfileUseCase{},
+		&usecase.ChangePasswordUseCase{},
+		&usecase.EnrollTOTPUseCase{},
+		&usecase.ConfirmTOTPUseCase{},
+		&usecase.ListSessionsUseCase{},
+		&usecase.RevokeSessionUseCase{},
 	)
 	
 	if handler == nil {
@@ -75,6 +82,12 @@ func TestUserHandler_CreateUser_InvalidJSON(t *testing.T) {
 		&usecase.CreateUserUseCase{},
 		&usecase.LoginUseCase{},
 		&usecase.GetUserUseCase{},
+		&usecase.UpdateProfileUseCase{},
+		&usecase.ChangePasswordUseCase{},
+		&usecase.EnrollTOTPUseCase{},
+		&usecase.ConfirmTOTPUseCase{},
+		&usecase.ListSessionsUseCase{},
+		&usecase.RevokeSessionUseCase{},
 	)
 
 	router := setupRouter(handler)
@@ -98,6 +111,12 @@ func TestUserHandler_Login_InvalidJSON(t *testing.T) {
 		&usecase.CreateUserUseCase{},
 		&usecase.LoginUseCase{},
 		&usecase.GetUserUseCase{},
+		&usecase.UpdateProfileUseCase{},
+		&usecase.ChangePasswordUseCase{},
+		&usecase.EnrollTOTPUseCase{},
+		&usecase.ConfirmTOTPUseCase{},
+		&usecase.ListSessionsUseCase{},
+		&usecase.RevokeSessionUseCase{},
 	)
 
 	router := setupRouter(handler)
@@ -115,3 +134,32 @@ func TestUserHandler_Login_InvalidJSON(t *testing.T) {
 	}
 }
 
+func TestUserHandler_UpdateProfile_WithoutPrincipal(t *testing.T) {
+	// Arrange
+	handler := handlers.NewUserHandler(
+		&usecase.CreateUserUseCase{},
+		&usecase.LoginUseCase{},
+		&usecase.GetUserUseCase{},
+		&usecase.UpdateProfileUseCase{},
+		&usecase.ChangePasswordUseCase{},
+		&usecase.EnrollTOTPUseCase{},
+		&usecase.ConfirmTOTPUseCase{},
+		&usecase.ListSessionsUseCase{},
+		&usecase.RevokeSessionUseCase{},
+	)
+
+	router := setupRouter(handler)
+
+	req, _ := http.NewRequest("PATCH", "/api/v1/users/me", bytes.NewBufferString(`{"name":"Nuevo Nombre"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status code 401, got %d", w.Code)
+	}
+}
+
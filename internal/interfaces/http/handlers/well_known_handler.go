@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"user-service/internal/domain"
+)
+
+type WellKnownHandler struct {
+	jwtService domain.JWTService
+	issuer     string
+	maxAge     int
+}
+
+func NewWellKnownHandler(
+	jwtService domain.JWTService,
+	issuer string,
+	maxAgeSeconds int,
+) *WellKnownHandler {
+	return &WellKnownHandler{
+		jwtService: jwtService,
+		issuer:     strings.TrimSuffix(issuer, "/"),
+		maxAge:     maxAgeSeconds,
+	}
+}
+
+type JWKSResponse struct {
+	Keys []domain.JSONWebKey `json:"keys"`
+}
+
+type OpenIDConfigurationResponse struct {
+	Issuer                           string   `json:"issuer"`
+	JWKSURI                          string   `json:"jwks_uri"`
+	TokenEndpoint                    string   `json:"token_endpoint"`
+	GrantTypesSupported              []string `json:"grant_types_supported"`
+	TokenEndpointAuthMethods         []string `json:"token_endpoint_auth_methods_supported"`
+	ResponseTypesSupported           []string `json:"response_types_supported"`
+	SubjectTypesSupported            []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+}
+
+// @Summary Claves públicas de verificación (JWKS)
+// @Tags well-known
+// @Produce json
+// @Success 200 {object} handlers.JWKSResponse
+// @Router /.well-known/jwks.json [get]
+func (h *WellKnownHandler) JWKS(c *gin.Context) {
+	h.setCacheHeaders(c)
+	c.JSON(http.StatusOK, JWKSResponse{
+		Keys: h.jwtService.PublicKeys(),
+	})
+}
+
+// @Summary Documento de descubrimiento OpenID
+// @Tags well-known
+// @Produce json
+// @Success 200 {object} handlers.OpenIDConfigurationResponse
+// @Router /.well-known/openid-configuration [get]
+func (h *WellKnownHandler) OpenIDConfiguration(c *gin.Context) {
+	h.setCacheHeaders(c)
+	c.JSON(http.StatusOK, OpenIDConfigurationResponse{
+		Issuer:                           h.issuer,
+		JWKSURI:                          h.issuer + "/.well-known/jwks.json",
+		TokenEndpoint:                    h.issuer + "/oauth/token",
+		GrantTypesSupported:              []string{"client_credentials"},
+		TokenEndpointAuthMethods:         []string{"client_secret_basic", "client_secret_post"},
+		ResponseTypesSupported:           []string{"token"},
+		SubjectTypesSupported:            []string{"public"},
+		IDTokenSigningAlgValuesSupported: []string{h.jwtService.SigningAlgorithm()},
+	})
+}
+
+// setCacheHeaders permite a los verificadores cachear las claves; durante una
+// rotación la clave nueva debe publicarse al menos maxAge segundos antes de
+// activarse.
+func (h *WellKnownHandler) setCacheHeaders(c *gin.Context) {
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", h.maxAge))
+}
+
@@ -0,0 +1,105 @@
+package handlers_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"user-service/internal/infrastructure/jwt"
+	"user-service/internal/interfaces/http/handlers"
+)
+
+func setupWellKnownRouter(handler *handlers.WellKnownHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/.well-known/jwks.json", handler.JWKS)
+	router.GET("/.well-known/openid-configuration", handler.OpenIDConfiguration)
+	return router
+}
+
+func TestWellKnownHandler_JWKS(t *testing.T) {
+	// Arrange
+	_, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+	key, _ := jwt.NewSigningKey("2025-01", privateKey)
+	keyring, _ := jwt.NewKeyring(key)
+	jwtService := jwt.NewJWTServiceWithKeyring(keyring, "https://users.example.com", "user-service", 15, 720)
+
+	router := setupWellKnownRouter(handlers.NewWellKnownHandler(jwtService, "https://users.example.com/", 300))
+
+	req, _ := http.NewRequest("GET", "/.well-known/jwks.json", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status code 200, got %d", w.Code)
+	}
+
+	if cacheControl := w.Header().Get("Cache-Control"); cacheControl != "public, max-age=300" {
+		t.Errorf("Unexpected Cache-Control header: %q", cacheControl)
+	}
+
+	var body handlers.JWKSResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Error parsing response: %v", err)
+	}
+
+	if len(body.Keys) != 1 || body.Keys[0].Kid != "2025-01" || body.Keys[0].Kty != "OKP" {
+		t.Errorf("Unexpected JWKS: %+v", body.Keys)
+	}
+}
+
+func TestWellKnownHandler_JWKS_HMACIsNotPublished(t *testing.T) {
+	// Arrange
+	jwtService, _ := jwt.NewJWTService("test-secret-key-min-32-characters-long", "https://users.example.com", "user-service", 15, 720)
+	router := setupWellKnownRouter(handlers.NewWellKnownHandler(jwtService, "https://users.example.com", 300))
+
+	req, _ := http.NewRequest("GET", "/.well-known/jwks.json", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	var body handlers.JWKSResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Error parsing response: %v", err)
+	}
+
+	if len(body.Keys) != 0 {
+		t.Errorf("Expected no published keys for HS256, got %d", len(body.Keys))
+	}
+}
+
+func TestWellKnownHandler_OpenIDConfiguration(t *testing.T) {
+	// Arrange
+	jwtService, _ := jwt.NewJWTService("test-secret-key-min-32-characters-long", "https://users.example.com", "user-service", 15, 720)
+	router := setupWellKnownRouter(handlers.NewWellKnownHandler(jwtService, "https://users.example.com/", 300))
+
+	req, _ := http.NewRequest("GET", "/.well-known/openid-configuration", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	var body handlers.OpenIDConfigurationResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Error parsing response: %v", err)
+	}
+
+	if body.Issuer != "https://users.example.com" {
+		t.Errorf("Unexpected issuer: %s", body.Issuer)
+	}
+
+	if body.JWKSURI != "https://users.example.com/.well-known/jwks.json" {
+		t.Errorf("Unexpected jwks_uri: %s", body.JWKSURI)
+	}
+}
+
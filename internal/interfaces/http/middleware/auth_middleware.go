@@ -6,9 +6,26 @@ import (
 
 	"github.com/gin-gonic/gin"
 	domain "user-service/internal/domain"
+	"user-service/pkg/errors"
 )
 
-func AuthMiddleware(jwtService domain.JWTService) gin.HandlerFunc {
+// PrincipalKey es la clave del contexto de gin donde AuthMiddleware deja el
+// *domain.Principal autenticado.
+const PrincipalKey = "principal"
+
+// CurrentPrincipal devuelve el principal autenticado de la petición.
+func CurrentPrincipal(c *gin.Context) (*domain.Principal, bool) {
+	value, exists := c.Get(PrincipalKey)
+	if !exists {
+		return nil, false
+	}
+	principal, ok := value.(*domain.Principal)
+	return principal, ok
+}
+
+// AuthMiddleware acepta access tokens (Authorization: Bearer <jwt>) y API keys
+// (Authorization: ApiKey <key>).
+func AuthMiddleware(jwtService domain.JWTService, revocationStore domain.TokenRevocationStore, apiKeyAuthenticator domain.APIKeyAuthenticator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -18,6 +35,10 @@ func AuthMiddleware(jwtService domain.JWTService) gin.HandlerFunc {
 		}
 
 		parts := strings.Split(authHeader, " ")
+		if len(parts) == 2 && parts[0] == "ApiKey" {
+			authenticateAPIKey(c, apiKeyAuthenticator, parts[1])
+			return
+		}
 		if len(parts) != 2 || parts[0] != "Bearer" {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "formato de token inválido"})
 			c.Abort()
@@ -26,14 +47,106 @@ func AuthMiddleware(jwtService domain.JWTService) gin.HandlerFunc {
 
 		token := parts[1]
 
-		userID, err := jwtService.ValidateToken(token)
+		principal, err := jwtService.ValidateToken(token)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "token inválido o expirado"})
 			c.Abort()
 			return
 		}
 
-		c.Set("user_id", userID)
+		revoked, err := revocationStore.IsRevoked(c.Request.Context(), principal)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "error al verificar token"})
+			c.Abort()
+			return
+		}
+		if revoked {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "token revocado"})
+			c.Abort()
+			return
+		}
+
+		c.Set(PrincipalKey, principal)
+		c.Next()
+	}
+}
+
+func authenticateAPIKey(c *gin.Context, apiKeyAuthenticator domain.APIKeyAuthenticator, key string) {
+	principal, err := apiKeyAuthenticator.Authenticate(c.Request.Context(), key)
+	if err != nil {
+		if errWithCode, ok := err.(*errors.ErrorWithCode); ok && errWithCode.Err != nil {
+			c.JSON(errWithCode.Code, gin.H{"error": errWithCode.Err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "error al verificar API key"})
+		}
+		c.Abort()
+		return
+	}
+
+	c.Set(PrincipalKey, principal)
+	c.Next()
+}
+
+// RequireUser rechaza los tokens de clientes OAuth2, que no actúan en nombre
+// de ningún usuario. Se monta en las rutas /users/me.
+func RequireUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := CurrentPrincipal(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrUnauthorized.Error()})
+			c.Abort()
+			return
+		}
+
+		if principal.IsClient() {
+			c.JSON(http.StatusForbidden, gin.H{"error": errors.ErrForbidden.Error()})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RejectAPIKey rechaza las peticiones autenticadas con API key. Se monta en
+// las rutas que gestionan credenciales o sesiones, que exigen un login
+// interactivo.
+func RejectAPIKey() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := CurrentPrincipal(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrUnauthorized.Error()})
+			c.Abort()
+			return
+		}
+
+		if principal.IsAPIKey() {
+			c.JSON(http.StatusForbidden, gin.H{"error": errors.ErrForbidden.Error()})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RejectRestricted rechaza los tokens restringidos de contraseña expirada.
+// Se monta en todas las rutas protegidas salvo el cambio de contraseña.
+func RejectRestricted() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := CurrentPrincipal(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrUnauthorized.Error()})
+			c.Abort()
+			return
+		}
+
+		if principal.IsRestricted() {
+			c.JSON(http.StatusForbidden, gin.H{"error": errors.ErrPasswordExpired.Error()})
+			c.Abort()
+			return
+		}
+
 		c.Next()
 	}
 }
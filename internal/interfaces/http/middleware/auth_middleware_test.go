@@ -0,0 +1,71 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"user-service/internal/domain"
+	"user-service/internal/interfaces/http/middleware"
+	"user-service/pkg/errors"
+)
+
+type stubAPIKeyAuthenticator struct {
+	keys map[string]*domain.Principal
+}
+
+func (s *stubAPIKeyAuthenticator) Authenticate(ctx context.Context, key string) (*domain.Principal, error) {
+	principal, ok := s.keys[key]
+	if !ok {
+		return nil, errors.NewErrorWithCode(401, "API key inválida", errors.ErrInvalidAPIKey)
+	}
+	return principal, nil
+}
+
+func TestAuthMiddleware_APIKey(t *testing.T) {
+	authenticator := &stubAPIKeyAuthenticator{keys: map[string]*domain.Principal{
+		"ak_valid": {UserID: "user-1", APIKeyID: "key-1"},
+	}}
+
+	tests := []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{"valid key", "ApiKey ak_valid", http.StatusOK},
+		{"unknown key", "ApiKey ak_unknown", http.StatusUnauthorized},
+		{"unknown scheme", "Basic dXNlcjpwYXNz", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			var principal *domain.Principal
+			router.GET("/resource", middleware.AuthMiddleware(nil, nil, authenticator), func(c *gin.Context) {
+				principal, _ = middleware.CurrentPrincipal(c)
+				c.Status(http.StatusOK)
+			})
+
+			req, _ := http.NewRequest("GET", "/resource", nil)
+			req.Header.Set("Authorization", tt.header)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			if w.Code != tt.want {
+				t.Fatalf("Expected status code %d, got %d", tt.want, w.Code)
+			}
+
+			if tt.want == http.StatusOK && (principal == nil || !principal.IsAPIKey()) {
+				t.Errorf("Expected API key principal, got %+v", principal)
+			}
+		})
+	}
+}
+
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	domain "user-service/internal/domain"
+)
+
+// CorrelationIDHeader relaciona una petición con los eventos que origina.
+const CorrelationIDHeader = "X-Correlation-ID"
+
+// Solo se aceptan IDs cortos y sin caracteres de control, que acaban en logs
+// y en los eventos.
+var validCorrelationID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// CorrelationID reutiliza el X-Correlation-ID recibido o genera uno nuevo, lo
+// devuelve en la respuesta y lo deja en el contexto de la petición.
+func CorrelationID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		correlationID := c.GetHeader(CorrelationIDHeader)
+		if !validCorrelationID.MatchString(correlationID) {
+			correlationID = uuid.NewString()
+		}
+
+		c.Header(CorrelationIDHeader, correlationID)
+		c.Request = c.Request.WithContext(domain.WithCorrelationID(c.Request.Context(), correlationID))
+
+		c.Next()
+	}
+}
+
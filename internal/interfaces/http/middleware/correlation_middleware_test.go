@@ -0,0 +1,61 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"user-service/internal/domain"
+	"user-service/internal/interfaces/http/middleware"
+)
+
+func TestCorrelationID(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		generate bool
+	}{
+		{"propagates valid header", "req-123_abc.def", false},
+		{"generates when missing", "", true},
+		{"replaces invalid header", "bad id\r\ninjected", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			gin.SetMode(gin.TestMode)
+			var fromContext string
+			router := gin.New()
+			router.Use(middleware.CorrelationID())
+			router.GET("/resource", func(c *gin.Context) {
+				fromContext = domain.CorrelationIDFromContext(c.Request.Context())
+				c.Status(http.StatusOK)
+			})
+
+			req, _ := http.NewRequest("GET", "/resource", nil)
+			if tt.header != "" {
+				req.Header.Set(middleware.CorrelationIDHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			returned := w.Header().Get(middleware.CorrelationIDHeader)
+			if returned == "" || returned != fromContext {
+				t.Fatalf("Expected the same correlation ID in response and context, got %q and %q", returned, fromContext)
+			}
+
+			if !tt.generate && returned != tt.header {
+				t.Errorf("Expected %q to be propagated, got %q", tt.header, returned)
+			}
+
+			if tt.generate && returned == tt.header {
+				t.Errorf("Expected a new correlation ID, got %q", returned)
+			}
+		})
+	}
+}
+
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"user-service/pkg/errors"
+)
+
+// RequireRole deja pasar si el principal tiene alguno de los roles indicados.
+// Debe montarse después de AuthMiddleware.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := CurrentPrincipal(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrUnauthorized.Error()})
+			c.Abort()
+			return
+		}
+
+		for _, role := range roles {
+			if principal.HasRole(role) {
+				c.Next()
+				return
+			}
+		}
+
+		c.JSON(http.StatusForbidden, gin.H{"error": errors.ErrForbidden.Error()})
+		c.Abort()
+	}
+}
+
+// RequirePermission deja pasar si el principal tiene todos los permisos
+// indicados, ya sea como scope del token o a través de sus roles.
+// Debe montarse después de AuthMiddleware.
+func RequirePermission(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := CurrentPrincipal(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrUnauthorized.Error()})
+			c.Abort()
+			return
+		}
+
+		for _, permission := range permissions {
+			if !principal.HasPermission(permission) {
+				c.JSON(http.StatusForbidden, gin.H{"error": errors.ErrForbidden.Error()})
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
@@ -0,0 +1,169 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"user-service/internal/domain"
+	"user-service/internal/interfaces/http/middleware"
+)
+
+func setupRBACRouter(principal *domain.Principal, guard gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/resource", func(c *gin.Context) {
+		if principal != nil {
+			c.Set(middleware.PrincipalKey, principal)
+		}
+		c.Next()
+	}, guard, func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func serve(router *gin.Engine) int {
+	req, _ := http.NewRequest("GET", "/resource", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestRequireRole(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *domain.Principal
+		want      int
+	}{
+		{"admin", &domain.Principal{Roles: []string{domain.RoleAdmin}}, http.StatusOK},
+		{"user", &domain.Principal{Roles: []string{domain.RoleUser}}, http.StatusForbidden},
+		{"unauthenticated", nil, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			router := setupRBACRouter(tt.principal, middleware.RequireRole(domain.RoleAdmin))
+
+			// Act
+			code := serve(router)
+
+			// Assert
+			if code != tt.want {
+				t.Errorf("Expected status code %d, got %d", tt.want, code)
+			}
+		})
+	}
+}
+
+func TestRequirePermission(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *domain.Principal
+		want      int
+	}{
+		{"granted by role", &domain.Principal{Roles: []string{domain.RoleSupport}}, http.StatusOK},
+		{"granted by scope", &domain.Principal{Scopes: []string{domain.PermissionUsersRead}}, http.StatusOK},
+		{"missing", &domain.Principal{Roles: []string{domain.RoleUser}}, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			router := setupRBACRouter(tt.principal, middleware.RequirePermission(domain.PermissionUsersRead))
+
+			// Act
+			code := serve(router)
+
+			// Assert
+			if code != tt.want {
+				t.Errorf("Expected status code %d, got %d", tt.want, code)
+			}
+		})
+	}
+}
+
+
+func TestRejectRestricted(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *domain.Principal
+		want      int
+	}{
+		{"full access", &domain.Principal{Roles: []string{domain.RoleUser}}, http.StatusOK},
+		{"password expired", &domain.Principal{Scopes: []string{domain.ScopePasswordChange}}, http.StatusForbidden},
+		{"unauthenticated", nil, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			router := setupRBACRouter(tt.principal, middleware.RejectRestricted())
+
+			// Act
+			code := serve(router)
+
+			// Assert
+			if code != tt.want {
+				t.Errorf("Expected status code %d, got %d", tt.want, code)
+			}
+		})
+	}
+}
+
+func TestRejectAPIKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *domain.Principal
+		want      int
+	}{
+		{"access token", &domain.Principal{Roles: []string{domain.RoleUser}}, http.StatusOK},
+		{"api key", &domain.Principal{APIKeyID: "key-1"}, http.StatusForbidden},
+		{"unauthenticated", nil, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			router := setupRBACRouter(tt.principal, middleware.RejectAPIKey())
+
+			// Act
+			code := serve(router)
+
+			// Assert
+			if code != tt.want {
+				t.Errorf("Expected status code %d, got %d", tt.want, code)
+			}
+		})
+	}
+}
+
+func TestRequireUser(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *domain.Principal
+		want      int
+	}{
+		{"user", &domain.Principal{UserID: "user-1"}, http.StatusOK},
+		{"oauth client", &domain.Principal{ClientID: "client-1", Scopes: []string{domain.PermissionUsersRead}}, http.StatusForbidden},
+		{"unauthenticated", nil, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			router := setupRBACRouter(tt.principal, middleware.RequireUser())
+
+			// Act
+			code := serve(router)
+
+			// Assert
+			if code != tt.want {
+				t.Errorf("Expected status code %d, got %d", tt.want, code)
+			}
+		})
+	}
+}
+
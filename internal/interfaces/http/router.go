@@ -15,26 +15,90 @@ import (
 // @securityDefinitions.apikey BearerAuth
 // @in header
 // @name Authorization
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name Authorization
 func SetupRouter(
 	userHandler *handlers.UserHandler,
+	authHandler *handlers.AuthHandler,
+	adminHandler *handlers.AdminHandler,
+	apiKeyHandler *handlers.APIKeyHandler,
+	oauthHandler *handlers.OAuthHandler,
+	wellKnownHandler *handlers.WellKnownHandler,
 	jwtService domain.JWTService,
+	revocationStore domain.TokenRevocationStore,
+	apiKeyAuthenticator domain.APIKeyAuthenticator,
 ) *gin.Engine {
 	router := gin.Default()
+	router.Use(middleware.CorrelationID())
 
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{"status": "ok"})
 	})
 
+	router.GET("/.well-known/jwks.json", wellKnownHandler.JWKS)
+	router.GET("/.well-known/openid-configuration", wellKnownHandler.OpenIDConfiguration)
+	router.POST("/oauth/token", oauthHandler.Token)
+
 	api := router.Group("/api/v1")
 	{
 		api.POST("/users", userHandler.CreateUser)
 		api.POST("/auth/login", userHandler.Login)
+		api.POST("/auth/refresh", authHandler.RefreshToken)
+		api.POST("/auth/password/forgot", authHandler.ForgotPassword)
+		api.POST("/auth/password/reset", authHandler.ResetPassword)
+		api.POST("/auth/verify-email", authHandler.VerifyEmail)
+		api.POST("/auth/verify-email/resend", authHandler.ResendVerification)
+		api.POST("/auth/mfa/verify", authHandler.VerifyMFA)
+	}
+
+	authenticated := api.Group("")
+	authenticated.Use(middleware.AuthMiddleware(jwtService, revocationStore, apiKeyAuthenticator))
+	{
+		// Única ruta que acepta el token restringido de contraseña expirada
+		authenticated.POST("/users/me/password", middleware.RequireUser(), middleware.RejectAPIKey(), userHandler.ChangePassword)
+	}
+
+	protected := authenticated.Group("")
+	protected.Use(middleware.RejectRestricted())
+
+	// Los clientes OAuth2 no tienen usuario propio: solo acceden a /admin
+	me := protected.Group("")
+	me.Use(middleware.RequireUser())
+	{
+		me.GET("/users/me", userHandler.GetUser)
+		me.PATCH("/users/me", userHandler.UpdateProfile)
+	}
+
+	// Credenciales y sesiones solo se gestionan con un login interactivo
+	interactive := me.Group("")
+	interactive.Use(middleware.RejectAPIKey())
+	{
+		interactive.POST("/users/me/mfa/totp", userHandler.EnrollTOTP)
+		interactive.POST("/users/me/mfa/totp/confirm", userHandler.ConfirmTOTP)
+		interactive.GET("/users/me/sessions", userHandler.ListSessions)
+		interactive.DELETE("/users/me/sessions/:id", userHandler.RevokeSession)
+		interactive.POST("/users/me/api-keys", apiKeyHandler.CreateOwn)
+		interactive.GET("/users/me/api-keys", apiKeyHandler.ListOwn)
+		interactive.DELETE("/users/me/api-keys/:id", apiKeyHandler.RevokeOwn)
+		interactive.POST("/auth/logout", authHandler.Logout)
+		interactive.POST("/auth/logout-all", authHandler.LogoutAll)
 	}
 
-	protected := api.Group("")
-	protected.Use(middleware.AuthMiddleware(jwtService))
+	admin := protected.Group("/admin")
 	{
-		protected.GET("/users/me", userHandler.GetUser)
+		admin.GET("/users", middleware.RequirePermission(domain.PermissionUsersRead), adminHandler.ListUsers)
+		admin.GET("/users/:id", middleware.RequirePermission(domain.PermissionUsersRead), adminHandler.GetUser)
+		admin.POST("/users/:id/suspend", middleware.RequirePermission(domain.PermissionUsersWrite), adminHandler.SuspendUser)
+		admin.POST("/users/:id/reactivate", middleware.RequirePermission(domain.PermissionUsersWrite), adminHandler.ReactivateUser)
+		admin.POST("/users/:id/unlock", middleware.RequirePermission(domain.PermissionUsersWrite), adminHandler.UnlockUser)
+		admin.DELETE("/users/:id", middleware.RequirePermission(domain.PermissionUsersDelete), adminHandler.DeleteUser)
+		admin.GET("/users/:id/api-keys", middleware.RequirePermission(domain.PermissionUsersRead), apiKeyHandler.ListForUser)
+		admin.POST("/users/:id/api-keys", middleware.RequirePermission(domain.PermissionUsersWrite), apiKeyHandler.CreateForUser)
+		admin.DELETE("/users/:id/api-keys/:key_id", middleware.RequirePermission(domain.PermissionUsersWrite), apiKeyHandler.RevokeForUser)
+		admin.GET("/oauth-clients", middleware.RequirePermission(domain.PermissionClientsRead), oauthHandler.ListClients)
+		admin.POST("/oauth-clients", middleware.RequirePermission(domain.PermissionClientsWrite), oauthHandler.CreateClient)
+		admin.DELETE("/oauth-clients/:client_id", middleware.RequirePermission(domain.PermissionClientsWrite), oauthHandler.DisableClient)
 	}
 
 	return router
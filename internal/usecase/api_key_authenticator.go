@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+)
+
+// apiKeyUsageResolution limita las escrituras de last_used_at: una key muy
+// usada no actualiza la fila en cada petición.
+const apiKeyUsageResolution = time.Minute
+
+// APIKeyAuthenticator valida las API keys para AuthMiddleware.
+type APIKeyAuthenticator struct {
+	userRepo   domain.UserRepository
+	apiKeyRepo domain.APIKeyRepository
+}
+
+func NewAPIKeyAuthenticator(userRepo domain.UserRepository, apiKeyRepo domain.APIKeyRepository) *APIKeyAuthenticator {
+	return &APIKeyAuthenticator{
+		userRepo:   userRepo,
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+// Authenticate devuelve un principal sin roles cuyos scopes son los de la key
+// que el usuario sigue teniendo: si pierde un rol, sus keys pierden los
+// permisos correspondientes.
+func (a *APIKeyAuthenticator) Authenticate(ctx context.Context, key string) (*domain.Principal, error) {
+	if !strings.HasPrefix(key, domain.APIKeyPrefix) {
+		return nil, errors.NewErrorWithCode(401, "API key inválida", errors.ErrInvalidAPIKey)
+	}
+
+	stored, err := a.apiKeyRepo.FindByHash(ctx, domain.HashToken(key))
+	if err != nil {
+		return nil, errors.NewErrorWithCode(401, "API key inválida", errors.ErrInvalidAPIKey)
+	}
+
+	now := time.Now()
+	if stored.RevokedAt != nil || stored.IsExpired(now) {
+		return nil, errors.NewErrorWithCode(401, "API key inválida", errors.ErrInvalidAPIKey)
+	}
+
+	user, err := a.userRepo.FindByID(ctx, stored.UserID.String())
+	if err != nil {
+		return nil, errors.NewErrorWithCode(401, "API key inválida", errors.ErrInvalidAPIKey)
+	}
+
+	if user.IsSuspended() {
+		return nil, errors.NewErrorWithCode(403, "Usuario suspendido", errors.ErrUserSuspended)
+	}
+
+	// El registro de uso es informativo: un fallo al guardarlo no rechaza la
+	// petición.
+	if stored.LastUsedAt == nil || now.Sub(*stored.LastUsedAt) >= apiKeyUsageResolution {
+		a.apiKeyRepo.MarkUsed(ctx, stored.ID.String(), now)
+	}
+
+	granted := make(map[string]bool)
+	for _, permission := range domain.PermissionsForRoles(user.Roles()) {
+		granted[permission] = true
+	}
+	scopes := []string{}
+	for _, scope := range stored.ScopeList() {
+		if granted[scope] {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return &domain.Principal{
+		UserID:   user.ID.String(),
+		Scopes:   scopes,
+		APIKeyID: stored.ID.String(),
+	}, nil
+}
+
@@ -0,0 +1,119 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/usecase"
+)
+
+func seedAPIKey(t *testing.T, repo *mockAPIKeyRepository, user *domain.User, scopes []string, expiresAt *time.Time) (string, *domain.APIKey) {
+	t.Helper()
+	raw, key, err := domain.NewAPIKey(user.ID, "script", scopes, expiresAt)
+	if err != nil {
+		t.Fatalf("Expected no error generating key, got %v", err)
+	}
+	repo.Create(context.Background(), key)
+	return raw, key
+}
+
+func TestAPIKeyAuthenticator_Authenticate_Success(t *testing.T) {
+	// Arrange
+	user, userRepo := newRoleUser(domain.RoleAdmin)
+	apiKeyRepo := newMockAPIKeyRepository()
+	raw, key := seedAPIKey(t, apiKeyRepo, user, []string{domain.PermissionUsersRead}, nil)
+
+	authenticator := usecase.NewAPIKeyAuthenticator(userRepo, apiKeyRepo)
+
+	// Act
+	principal, err := authenticator.Authenticate(context.Background(), raw)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if principal.UserID != user.ID.String() || principal.APIKeyID != key.ID.String() {
+		t.Errorf("Unexpected principal %+v", principal)
+	}
+
+	if len(principal.Roles) != 0 || !principal.HasPermission(domain.PermissionUsersRead) || principal.HasPermission(domain.PermissionUsersWrite) {
+		t.Errorf("Expected only the key scopes, got %+v", principal)
+	}
+
+	if key.LastUsedAt == nil {
+		t.Error("Expected last used timestamp to be recorded")
+	}
+}
+
+func TestAPIKeyAuthenticator_Authenticate_DropsScopesLostByUser(t *testing.T) {
+	// Arrange
+	user, userRepo := newRoleUser(domain.RoleAdmin)
+	apiKeyRepo := newMockAPIKeyRepository()
+	raw, _ := seedAPIKey(t, apiKeyRepo, user, []string{domain.PermissionUsersRead, domain.PermissionUsersDelete}, nil)
+	user.Role = domain.RoleSupport
+
+	authenticator := usecase.NewAPIKeyAuthenticator(userRepo, apiKeyRepo)
+
+	// Act
+	principal, err := authenticator.Authenticate(context.Background(), raw)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if principal.HasPermission(domain.PermissionUsersDelete) || !principal.HasPermission(domain.PermissionUsersRead) {
+		t.Errorf("Expected scopes limited to current role, got %v", principal.Scopes)
+	}
+}
+
+func TestAPIKeyAuthenticator_Authenticate_Rejected(t *testing.T) {
+	expired := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name  string
+		setup func(user *domain.User, key *domain.APIKey)
+		code  int
+	}{
+		{"revoked", func(user *domain.User, key *domain.APIKey) {
+			now := time.Now()
+			key.RevokedAt = &now
+		}, 401},
+		{"expired", func(user *domain.User, key *domain.APIKey) { key.ExpiresAt = &expired }, 401},
+		{"suspended user", func(user *domain.User, key *domain.APIKey) { user.Status = domain.UserStatusSuspended }, 403},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			user, userRepo := newRoleUser(domain.RoleUser)
+			apiKeyRepo := newMockAPIKeyRepository()
+			raw, key := seedAPIKey(t, apiKeyRepo, user, nil, nil)
+			tt.setup(user, key)
+
+			authenticator := usecase.NewAPIKeyAuthenticator(userRepo, apiKeyRepo)
+
+			// Act
+			_, err := authenticator.Authenticate(context.Background(), raw)
+
+			// Assert
+			assertLoginCode(t, err, tt.code)
+		})
+	}
+}
+
+func TestAPIKeyAuthenticator_Authenticate_UnknownKey(t *testing.T) {
+	// Arrange
+	_, userRepo := newRoleUser(domain.RoleUser)
+	authenticator := usecase.NewAPIKeyAuthenticator(userRepo, newMockAPIKeyRepository())
+
+	// Act
+	_, err := authenticator.Authenticate(context.Background(), domain.APIKeyPrefix+"desconocida")
+
+	// Assert
+	assertLoginCode(t, err, 401)
+}
+
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+)
+
+type ChangePasswordUseCase struct {
+	userRepo          domain.UserRepository
+	refreshTokenRepo  domain.RefreshTokenRepository
+	sessionRepo       domain.SessionRepository
+	revocationStore   domain.TokenRevocationStore
+	eventPublisher    domain.EventPublisher
+	jwtService        domain.JWTService
+	passwordHasher    domain.PasswordHasher
+	passwordValidator *PasswordValidator
+	passwordHistory   *PasswordHistory
+}
+
+func NewChangePasswordUseCase(
+	userRepo domain.UserRepository,
+	refreshTokenRepo domain.RefreshTokenRepository,
+	sessionRepo domain.SessionRepository,
+	revocationStore domain.TokenRevocationStore,
+	eventPublisher domain.EventPublisher,
+	jwtService domain.JWTService,
+	passwordHasher domain.PasswordHasher,
+	passwordValidator *PasswordValidator,
+	passwordHistory *PasswordHistory,
+) *ChangePasswordUseCase {
+	return &ChangePasswordUseCase{
+		userRepo:          userRepo,
+		refreshTokenRepo:  refreshTokenRepo,
+		sessionRepo:       sessionRepo,
+		revocationStore:   revocationStore,
+		eventPublisher:    eventPublisher,
+		jwtService:        jwtService,
+		passwordHasher:    passwordHasher,
+		passwordValidator: passwordValidator,
+		passwordHistory:   passwordHistory,
+	}
+}
+
+type ChangePasswordRequest struct {
+	Principal       *domain.Principal `json:"-"`
+	CurrentPassword string            `json:"current_password"`
+	NewPassword     string            `json:"new_password"`
+	IPAddress       string            `json:"-"`
+	UserAgent       string            `json:"-"`
+}
+
+// ChangePasswordResponse lleva un access token nuevo para la sesión actual,
+// ya que el anterior queda revocado junto con los del resto de sesiones. Si
+// el cambio se hizo con el token restringido de contraseña expirada, no hay
+// sesión que conservar y se abre una nueva con su refresh token.
+type ChangePasswordResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+func (uc *ChangePasswordUseCase) Execute(ctx context.Context, req ChangePasswordRequest) (*ChangePasswordResponse, error) {
+	user, err := uc.userRepo.FindByID(ctx, req.Principal.UserID)
+	if err != nil {
+		return nil, errors.NewErrorWithCode(404, "Usuario no encontrado", errors.ErrUserNotFound)
+	}
+
+	if !user.VerifyPassword(uc.passwordHasher, req.CurrentPassword) {
+		return nil, errors.NewErrorWithCode(401, "Contraseña actual incorrecta", errors.ErrInvalidCurrentPassword)
+	}
+
+	if err := uc.passwordValidator.Validate(ctx, req.NewPassword, user.Email, user.Name); err != nil {
+		return nil, err
+	}
+
+	if user.VerifyPassword(uc.passwordHasher, req.NewPassword) {
+		return nil, errors.NewErrorWithCode(400, "La nueva contraseña debe ser distinta de la actual", nil)
+	}
+
+	if err := uc.passwordHistory.CheckReuse(ctx, user, req.NewPassword); err != nil {
+		return nil, err
+	}
+
+	if err := user.HashPassword(uc.passwordHasher, req.NewPassword); err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al procesar contraseña", err)
+	}
+	now := time.Now()
+	user.PasswordChangedAt = &now
+
+	if err := uc.userRepo.Update(ctx, user, "password", "password_changed_at"); err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al actualizar contraseña", err)
+	}
+
+	if err := uc.passwordHistory.Record(ctx, user); err != nil {
+		return nil, err
+	}
+
+	if err := uc.revocationStore.RevokeAllForUser(ctx, user.ID.String(), now); err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al cerrar sesiones", err)
+	}
+
+	response, err := uc.renewSession(ctx, user, req)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		eventCtx := eventContext(ctx)
+		uc.eventPublisher.PublishPasswordChanged(
+			eventCtx,
+			user.ID.String(),
+			user.UpdatedAt.Unix(),
+		)
+	}()
+
+	return response, nil
+}
+
+func (uc *ChangePasswordUseCase) renewSession(ctx context.Context, user *domain.User, req ChangePasswordRequest) (*ChangePasswordResponse, error) {
+	principal := req.Principal
+	if principal.IsRestricted() {
+		if err := uc.refreshTokenRepo.RevokeAllForUser(ctx, user.ID.String()); err != nil {
+			return nil, errors.NewErrorWithCode(500, "Error al cerrar sesiones", err)
+		}
+
+		client := SessionClient{UserAgent: req.UserAgent, IPAddress: req.IPAddress}
+		tokens, err := startSession(ctx, uc.jwtService, uc.refreshTokenRepo, uc.sessionRepo, user, client)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ChangePasswordResponse{
+			Token:        tokens.accessToken,
+			RefreshToken: tokens.refreshToken,
+		}, nil
+	}
+
+	if err := uc.refreshTokenRepo.RevokeOtherFamilies(ctx, user.ID.String(), principal.SessionID); err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al cerrar sesiones", err)
+	}
+
+	accessToken, err := issueAccessToken(uc.jwtService, user, principal.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChangePasswordResponse{
+		Token: accessToken,
+	}, nil
+}
+
@@ -0,0 +1,218 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/usecase"
+	"user-service/pkg/errors"
+	"github.com/google/uuid"
+)
+
+func newPasswordUser(t *testing.T, password string) *domain.User {
+	user := &domain.User{
+		ID:     uuid.New(),
+		Email:  "test@example.com",
+		Name:   "Test User",
+		Role:   domain.RoleUser,
+		Status: domain.UserStatusActive,
+	}
+	if err := user.HashPassword(&mockPasswordHasher{}, password); err != nil {
+		t.Fatalf("Expected no error hashing password, got %v", err)
+	}
+	return user
+}
+
+func TestChangePasswordUseCase_Execute_Success(t *testing.T) {
+	// Arrange
+	user := newPasswordUser(t, "password123")
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+	refreshRepo := newMockRefreshTokenRepository()
+	revocationStore := newMockRevocationStore()
+	jwtService := &mockJWTService{}
+
+	current := seedRefreshTokenForUser(refreshRepo, "refresh-current", user.ID, time.Now().Add(time.Hour))
+	other := seedRefreshTokenForUser(refreshRepo, "refresh-other", user.ID, time.Now().Add(time.Hour))
+
+	useCase := usecase.NewChangePasswordUseCase(userRepo, refreshRepo, newMockSessionRepository(), revocationStore, &mockEventPublisher{}, jwtService, &mockPasswordHasher{}, newPasswordValidator(), newPasswordHistory())
+
+	// Act
+	response, err := useCase.Execute(context.Background(), usecase.ChangePasswordRequest{
+		Principal: &domain.Principal{
+			UserID:    user.ID.String(),
+			SessionID: current.FamilyID.String(),
+			IssuedAt:  time.Now().Add(-time.Minute),
+		},
+		CurrentPassword: "password123",
+		NewPassword:     "newpassword456",
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if response.Token == "" {
+		t.Error("Expected new access token")
+	}
+
+	if !user.VerifyPassword(&mockPasswordHasher{}, "newpassword456") {
+		t.Error("Expected new password to be stored")
+	}
+
+	if current.RevokedAt != nil {
+		t.Error("Expected current session to remain active")
+	}
+
+	if other.RevokedAt == nil {
+		t.Error("Expected other sessions to be revoked")
+	}
+
+	if _, ok := revocationStore.revokedBefore[user.ID.String()]; !ok {
+		t.Error("Expected access tokens to be revoked")
+	}
+
+	if len(jwtService.issued) != 1 || jwtService.issued[0].SessionID != current.FamilyID.String() {
+		t.Errorf("Expected token issued for current session, got %+v", jwtService.issued)
+	}
+}
+
+func TestChangePasswordUseCase_Execute_WrongCurrentPassword(t *testing.T) {
+	// Arrange
+	user := newPasswordUser(t, "password123")
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+	refreshRepo := newMockRefreshTokenRepository()
+	revocationStore := newMockRevocationStore()
+
+	useCase := usecase.NewChangePasswordUseCase(userRepo, refreshRepo, newMockSessionRepository(), revocationStore, &mockEventPublisher{}, &mockJWTService{}, &mockPasswordHasher{}, newPasswordValidator(), newPasswordHistory())
+
+	// Act
+	_, err := useCase.Execute(context.Background(), usecase.ChangePasswordRequest{
+		Principal:       &domain.Principal{UserID: user.ID.String()},
+		CurrentPassword: "wrongpassword",
+		NewPassword:     "newpassword456",
+	})
+
+	// Assert
+	errWithCode, ok := err.(*errors.ErrorWithCode)
+	if !ok || errWithCode.Code != 401 {
+		t.Fatalf("Expected 401 error, got %v", err)
+	}
+
+	if !user.VerifyPassword(&mockPasswordHasher{}, "password123") {
+		t.Error("Expected password to remain unchanged")
+	}
+
+	if len(revocationStore.revokedBefore) != 0 {
+		t.Error("Expected no sessions to be revoked")
+	}
+}
+
+func TestChangePasswordUseCase_Execute_PolicyViolation(t *testing.T) {
+	// Arrange
+	user := newPasswordUser(t, "password123")
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+
+	useCase := usecase.NewChangePasswordUseCase(userRepo, newMockRefreshTokenRepository(), newMockSessionRepository(), newMockRevocationStore(), &mockEventPublisher{}, &mockJWTService{}, &mockPasswordHasher{}, newPasswordValidator(), newPasswordHistory())
+
+	tests := []struct {
+		name        string
+		newPassword string
+	}{
+		{name: "too short", newPassword: "short"},
+		{name: "same as current", newPassword: "password123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := useCase.Execute(context.Background(), usecase.ChangePasswordRequest{
+				Principal:       &domain.Principal{UserID: user.ID.String()},
+				CurrentPassword: "password123",
+				NewPassword:     tt.newPassword,
+			})
+
+			// Assert
+			errWithCode, ok := err.(*errors.ErrorWithCode)
+			if !ok || errWithCode.Code != 400 {
+				t.Fatalf("Expected 400 error, got %v", err)
+			}
+		})
+	}
+}
+
+
+func TestChangePasswordUseCase_Execute_RejectsReusedPassword(t *testing.T) {
+	// Arrange
+	user := newPasswordUser(t, "password123")
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+	history := usecase.NewPasswordHistory(newMockPasswordHistoryRepository(), &mockPasswordHasher{}, 3)
+	if err := history.Record(context.Background(), user); err != nil {
+		t.Fatalf("Expected no error seeding history, got %v", err)
+	}
+
+	useCase := usecase.NewChangePasswordUseCase(userRepo, newMockRefreshTokenRepository(), newMockSessionRepository(), newMockRevocationStore(), &mockEventPublisher{}, &mockJWTService{}, &mockPasswordHasher{}, newPasswordValidator(), history)
+	principal := &domain.Principal{UserID: user.ID.String()}
+
+	if _, err := useCase.Execute(context.Background(), usecase.ChangePasswordRequest{Principal: principal, CurrentPassword: "password123", NewPassword: "newpassword456"}); err != nil {
+		t.Fatalf("Expected no error on first change, got %v", err)
+	}
+
+	// Act
+	_, err := useCase.Execute(context.Background(), usecase.ChangePasswordRequest{
+		Principal:       principal,
+		CurrentPassword: "newpassword456",
+		NewPassword:     "password123",
+	})
+
+	// Assert
+	rules := violatedRules(t, err)
+	if len(rules) != 1 || rules[0] != domain.PasswordRuleReused {
+		t.Errorf("Expected only %s, got %v", domain.PasswordRuleReused, rules)
+	}
+}
+
+func TestChangePasswordUseCase_Execute_RestrictedTokenStartsSession(t *testing.T) {
+	// Arrange
+	user := newPasswordUser(t, "password123")
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+	refreshRepo := newMockRefreshTokenRepository()
+	old := seedRefreshTokenForUser(refreshRepo, "refresh-old", user.ID, time.Now().Add(time.Hour))
+	jwtService := &mockJWTService{}
+
+	useCase := usecase.NewChangePasswordUseCase(userRepo, refreshRepo, newMockSessionRepository(), newMockRevocationStore(), &mockEventPublisher{}, jwtService, &mockPasswordHasher{}, newPasswordValidator(), newPasswordHistory())
+
+	// Act
+	response, err := useCase.Execute(context.Background(), usecase.ChangePasswordRequest{
+		Principal: &domain.Principal{
+			UserID: user.ID.String(),
+			Scopes: []string{domain.ScopePasswordChange},
+		},
+		CurrentPassword: "password123",
+		NewPassword:     "newpassword456",
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if response.Token == "" || response.RefreshToken == "" {
+		t.Fatal("Expected a new session with refresh token")
+	}
+
+	if old.RevokedAt == nil {
+		t.Error("Expected previous sessions to be revoked")
+	}
+
+	if len(jwtService.issued) != 1 || jwtService.issued[0].IsRestricted() || jwtService.issued[0].SessionID == "" {
+		t.Errorf("Expected unrestricted token for a new session, got %+v", jwtService.issued)
+	}
+
+	if user.PasswordChangedAt == nil {
+		t.Error("Expected password change time to be recorded")
+	}
+}
+
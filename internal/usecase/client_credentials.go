@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+)
+
+const GrantTypeClientCredentials = "client_credentials"
+
+// ClientCredentialsUseCase implementa el grant client_credentials (RFC 6749
+// §4.4): un servicio registrado cambia su client_id y secreto por un access
+// token sin usuario, con los scopes pedidos.
+type ClientCredentialsUseCase struct {
+	clientRepo     domain.OAuthClientRepository
+	jwtService     domain.JWTService
+	accessTokenTTL time.Duration
+}
+
+func NewClientCredentialsUseCase(
+	clientRepo domain.OAuthClientRepository,
+	jwtService domain.JWTService,
+	accessTokenTTL time.Duration,
+) *ClientCredentialsUseCase {
+	return &ClientCredentialsUseCase{
+		clientRepo:     clientRepo,
+		jwtService:     jwtService,
+		accessTokenTTL: accessTokenTTL,
+	}
+}
+
+// ClientCredentialsRequest lleva Scope como en el formulario: separados por
+// espacios. Sin scope se conceden todos los del cliente.
+type ClientCredentialsRequest struct {
+	GrantType    string
+	ClientID     string
+	ClientSecret string
+	Scope        string
+}
+
+// ClientCredentialsResponse sigue RFC 6749 §5.1; no hay refresh token.
+type ClientCredentialsResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	Scope       string `json:"scope,omitempty"`
+}
+
+func (uc *ClientCredentialsUseCase) Execute(ctx context.Context, req ClientCredentialsRequest) (*ClientCredentialsResponse, error) {
+	if req.GrantType != GrantTypeClientCredentials {
+		return nil, errors.NewErrorWithCode(400, "Solo se admite grant_type=client_credentials", errors.ErrUnsupportedGrantType)
+	}
+
+	client, err := uc.authenticateClient(ctx, req.ClientID, req.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes, err := requestedClientScopes(client, req.Scope)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, err := uc.jwtService.GenerateToken(domain.Principal{
+		ClientID: client.ID.String(),
+		Scopes:   scopes,
+	})
+	if err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al generar token", err)
+	}
+
+	return &ClientCredentialsResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(uc.accessTokenTTL.Seconds()),
+		Scope:       strings.Join(scopes, " "),
+	}, nil
+}
+
+// authenticateClient responde igual para un client_id desconocido, un secreto
+// incorrecto o un cliente desactivado.
+func (uc *ClientCredentialsUseCase) authenticateClient(ctx context.Context, clientID, clientSecret string) (*domain.OAuthClient, error) {
+	invalid := errors.NewErrorWithCode(401, "Autenticación de cliente fallida", errors.ErrInvalidClient)
+	if clientID == "" || clientSecret == "" {
+		return nil, invalid
+	}
+
+	client, err := uc.clientRepo.FindByID(ctx, clientID)
+	if err != nil {
+		return nil, invalid
+	}
+
+	secretHash := domain.HashToken(clientSecret)
+	if subtle.ConstantTimeCompare([]byte(secretHash), []byte(client.SecretHash)) != 1 || client.IsDisabled() {
+		return nil, invalid
+	}
+
+	return client, nil
+}
+
+func requestedClientScopes(client *domain.OAuthClient, scope string) ([]string, error) {
+	allowed := client.ScopeList()
+	requested := strings.Fields(scope)
+	if len(requested) == 0 {
+		return allowed, nil
+	}
+
+	allowedSet := make(map[string]bool)
+	for _, s := range allowed {
+		allowedSet[s] = true
+	}
+
+	seen := make(map[string]bool)
+	scopes := []string{}
+	for _, s := range requested {
+		if !allowedSet[s] {
+			return nil, errors.NewErrorWithCode(400, "Scope no permitido para el cliente: "+s, errors.ErrInvalidScope)
+		}
+		if !seen[s] {
+			seen[s] = true
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes, nil
+}
+
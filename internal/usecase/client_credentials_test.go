@@ -0,0 +1,177 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/usecase"
+	"user-service/pkg/errors"
+)
+
+func seedOAuthClient(t *testing.T, clientRepo *mockOAuthClientRepository, scopes ...string) (string, *domain.OAuthClient) {
+	t.Helper()
+	secret, client, err := domain.NewOAuthClient("facturación", scopes)
+	if err != nil {
+		t.Fatalf("Expected no error creating client, got %v", err)
+	}
+	if err := clientRepo.Create(context.Background(), client); err != nil {
+		t.Fatalf("Expected no error storing client, got %v", err)
+	}
+	return secret, client
+}
+
+func assertOAuthError(t *testing.T, err error, code int, sentinel error) {
+	t.Helper()
+	errWithCode, ok := err.(*errors.ErrorWithCode)
+	if !ok {
+		t.Fatalf("Expected ErrorWithCode, got %v", err)
+	}
+	if errWithCode.Code != code || errWithCode.Err != sentinel {
+		t.Errorf("Expected %d %v, got %d %v", code, sentinel, errWithCode.Code, errWithCode.Err)
+	}
+}
+
+func TestClientCredentialsUseCase_Execute_Success(t *testing.T) {
+	// Arrange
+	clientRepo := newMockOAuthClientRepository()
+	secret, client := seedOAuthClient(t, clientRepo, domain.PermissionUsersRead, domain.PermissionUsersWrite)
+	jwtService := &mockJWTService{}
+
+	useCase := usecase.NewClientCredentialsUseCase(clientRepo, jwtService, 15*time.Minute)
+
+	// Act
+	response, err := useCase.Execute(context.Background(), usecase.ClientCredentialsRequest{
+		GrantType:    usecase.GrantTypeClientCredentials,
+		ClientID:     client.ID.String(),
+		ClientSecret: secret,
+		Scope:        domain.PermissionUsersRead,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if response.TokenType != "Bearer" || response.ExpiresIn != 900 || response.Scope != domain.PermissionUsersRead {
+		t.Errorf("Unexpected response %+v", response)
+	}
+
+	if len(jwtService.issued) != 1 {
+		t.Fatalf("Expected one token to be issued, got %d", len(jwtService.issued))
+	}
+
+	principal := jwtService.issued[0]
+	if principal.ClientID != client.ID.String() || principal.UserID != "" {
+		t.Errorf("Expected a client principal, got %+v", principal)
+	}
+
+	if len(principal.Scopes) != 1 || principal.Scopes[0] != domain.PermissionUsersRead {
+		t.Errorf("Expected scopes [%s], got %v", domain.PermissionUsersRead, principal.Scopes)
+	}
+}
+
+func TestClientCredentialsUseCase_Execute_DefaultsToAllClientScopes(t *testing.T) {
+	// Arrange
+	clientRepo := newMockOAuthClientRepository()
+	secret, client := seedOAuthClient(t, clientRepo, domain.PermissionUsersRead, domain.PermissionUsersWrite)
+	jwtService := &mockJWTService{}
+
+	useCase := usecase.NewClientCredentialsUseCase(clientRepo, jwtService, 15*time.Minute)
+
+	// Act
+	response, err := useCase.Execute(context.Background(), usecase.ClientCredentialsRequest{
+		GrantType:    usecase.GrantTypeClientCredentials,
+		ClientID:     client.ID.String(),
+		ClientSecret: secret,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if response.Scope != client.Scopes {
+		t.Errorf("Expected scope %q, got %q", client.Scopes, response.Scope)
+	}
+}
+
+func TestClientCredentialsUseCase_Execute_InvalidClient(t *testing.T) {
+	clientRepo := newMockOAuthClientRepository()
+	secret, client := seedOAuthClient(t, clientRepo, domain.PermissionUsersRead)
+	disabledSecret, disabled := seedOAuthClient(t, clientRepo, domain.PermissionUsersRead)
+	now := time.Now()
+	disabled.DisabledAt = &now
+
+	tests := []struct {
+		name     string
+		clientID string
+		secret   string
+	}{
+		{"wrong secret", client.ID.String(), "incorrecto"},
+		{"unknown client", "00000000-0000-0000-0000-000000000000", secret},
+		{"missing secret", client.ID.String(), ""},
+		{"disabled client", disabled.ID.String(), disabledSecret},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			jwtService := &mockJWTService{}
+			useCase := usecase.NewClientCredentialsUseCase(clientRepo, jwtService, 15*time.Minute)
+
+			// Act
+			_, err := useCase.Execute(context.Background(), usecase.ClientCredentialsRequest{
+				GrantType:    usecase.GrantTypeClientCredentials,
+				ClientID:     tt.clientID,
+				ClientSecret: tt.secret,
+			})
+
+			// Assert
+			assertOAuthError(t, err, 401, errors.ErrInvalidClient)
+
+			if len(jwtService.issued) != 0 {
+				t.Error("Expected no token to be issued")
+			}
+		})
+	}
+}
+
+func TestClientCredentialsUseCase_Execute_ScopeNotAllowed(t *testing.T) {
+	// Arrange
+	clientRepo := newMockOAuthClientRepository()
+	secret, client := seedOAuthClient(t, clientRepo, domain.PermissionUsersRead)
+
+	useCase := usecase.NewClientCredentialsUseCase(clientRepo, &mockJWTService{}, 15*time.Minute)
+
+	// Act
+	_, err := useCase.Execute(context.Background(), usecase.ClientCredentialsRequest{
+		GrantType:    usecase.GrantTypeClientCredentials,
+		ClientID:     client.ID.String(),
+		ClientSecret: secret,
+		Scope:        domain.PermissionUsersRead + " " + domain.PermissionUsersDelete,
+	})
+
+	// Assert
+	assertOAuthError(t, err, 400, errors.ErrInvalidScope)
+}
+
+func TestClientCredentialsUseCase_Execute_UnsupportedGrantType(t *testing.T) {
+	// Arrange
+	clientRepo := newMockOAuthClientRepository()
+	secret, client := seedOAuthClient(t, clientRepo, domain.PermissionUsersRead)
+
+	useCase := usecase.NewClientCredentialsUseCase(clientRepo, &mockJWTService{}, 15*time.Minute)
+
+	// Act
+	_, err := useCase.Execute(context.Background(), usecase.ClientCredentialsRequest{
+		GrantType:    "password",
+		ClientID:     client.ID.String(),
+		ClientSecret: secret,
+	})
+
+	// Assert
+	assertOAuthError(t, err, 400, errors.ErrUnsupportedGrantType)
+}
+
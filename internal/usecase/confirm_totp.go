@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+)
+
+type ConfirmTOTPUseCase struct {
+	userRepo         domain.UserRepository
+	recoveryCodeRepo domain.RecoveryCodeRepository
+	cipher           domain.SecretCipher
+}
+
+func NewConfirmTOTPUseCase(
+	userRepo domain.UserRepository,
+	recoveryCodeRepo domain.RecoveryCodeRepository,
+	cipher domain.SecretCipher,
+) *ConfirmTOTPUseCase {
+	return &ConfirmTOTPUseCase{
+		userRepo:         userRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+		cipher:           cipher,
+	}
+}
+
+type ConfirmTOTPRequest struct {
+	UserID string `json:"-"`
+	Code   string `json:"code"`
+}
+
+// ConfirmTOTPResponse lleva los códigos de recuperación en claro; es la única
+// vez que se muestran.
+type ConfirmTOTPResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func (uc *ConfirmTOTPUseCase) Execute(ctx context.Context, req ConfirmTOTPRequest) (*ConfirmTOTPResponse, error) {
+	user, err := uc.userRepo.FindByID(ctx, req.UserID)
+	if err != nil {
+		return nil, errors.NewErrorWithCode(404, "Usuario no encontrado", errors.ErrUserNotFound)
+	}
+
+	if user.MFAEnabled {
+		return nil, errors.NewErrorWithCode(409, "MFA ya está activado", errors.ErrMFAAlreadyEnabled)
+	}
+
+	if user.TOTPSecret == "" {
+		return nil, errors.NewErrorWithCode(400, "MFA no está enrolado", errors.ErrMFANotEnrolled)
+	}
+
+	valid, err := verifyTOTPCode(uc.cipher, user, req.Code, time.Now())
+	if err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al verificar código", err)
+	}
+	if !valid {
+		return nil, errors.NewErrorWithCode(400, "Código MFA inválido", errors.ErrInvalidMFACode)
+	}
+
+	rawCodes, codes, err := generateRecoveryCodes(user)
+	if err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al generar códigos de recuperación", err)
+	}
+
+	if err := uc.recoveryCodeRepo.ReplaceForUser(ctx, user.ID.String(), codes); err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al guardar códigos de recuperación", err)
+	}
+
+	user.MFAEnabled = true
+	if err := uc.userRepo.Update(ctx, user, "mfa_enabled"); err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al activar MFA", err)
+	}
+
+	return &ConfirmTOTPResponse{
+		RecoveryCodes: rawCodes,
+	}, nil
+}
+
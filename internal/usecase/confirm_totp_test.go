@@ -0,0 +1,123 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/usecase"
+	"user-service/pkg/errors"
+	"user-service/pkg/totp"
+)
+
+// newEnrolledUser devuelve un usuario con un secreto TOTP pendiente de
+// confirmar y el secreto en claro.
+func newEnrolledUser(t *testing.T) (*domain.User, string) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatalf("Expected no error generating secret, got %v", err)
+	}
+	user := newPasswordUser(t, "password123")
+	user.TOTPSecret = "enc:" + secret
+	return user, secret
+}
+
+func currentTOTPCode(t *testing.T, secret string) string {
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	if err != nil {
+		t.Fatalf("Expected no error generating code, got %v", err)
+	}
+	return code
+}
+
+func TestConfirmTOTPUseCase_Execute_Success(t *testing.T) {
+	// Arrange
+	user, secret := newEnrolledUser(t)
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+	recoveryCodeRepo := newMockRecoveryCodeRepository()
+
+	useCase := usecase.NewConfirmTOTPUseCase(userRepo, recoveryCodeRepo, &mockCipher{})
+
+	req := usecase.ConfirmTOTPRequest{
+		UserID: user.ID.String(),
+		Code:   currentTOTPCode(t, secret),
+	}
+
+	// Act
+	response, err := useCase.Execute(context.Background(), req)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !user.MFAEnabled {
+		t.Error("Expected MFA to be enabled")
+	}
+
+	if len(response.RecoveryCodes) != 10 {
+		t.Fatalf("Expected 10 recovery codes, got %d", len(response.RecoveryCodes))
+	}
+
+	if len(recoveryCodeRepo.codes) != 10 {
+		t.Errorf("Expected 10 stored recovery codes, got %d", len(recoveryCodeRepo.codes))
+	}
+
+	for hash := range recoveryCodeRepo.codes {
+		for _, raw := range response.RecoveryCodes {
+			if hash == raw {
+				t.Fatal("Expected recovery codes to be stored hashed")
+			}
+		}
+	}
+}
+
+func TestConfirmTOTPUseCase_Execute_InvalidCode(t *testing.T) {
+	// Arrange
+	user, _ := newEnrolledUser(t)
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+
+	useCase := usecase.NewConfirmTOTPUseCase(userRepo, newMockRecoveryCodeRepository(), &mockCipher{})
+
+	req := usecase.ConfirmTOTPRequest{
+		UserID: user.ID.String(),
+		Code:   "000000",
+	}
+
+	// Act
+	_, err := useCase.Execute(context.Background(), req)
+
+	// Assert
+	errWithCode, ok := err.(*errors.ErrorWithCode)
+	if !ok || errWithCode.Code != 400 {
+		t.Fatalf("Expected status code 400, got %v", err)
+	}
+
+	if user.MFAEnabled {
+		t.Error("Expected MFA to stay disabled")
+	}
+}
+
+func TestConfirmTOTPUseCase_Execute_NotEnrolled(t *testing.T) {
+	// Arrange
+	user := newPasswordUser(t, "password123")
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+
+	useCase := usecase.NewConfirmTOTPUseCase(userRepo, newMockRecoveryCodeRepository(), &mockCipher{})
+
+	req := usecase.ConfirmTOTPRequest{
+		UserID: user.ID.String(),
+		Code:   "123456",
+	}
+
+	// Act
+	_, err := useCase.Execute(context.Background(), req)
+
+	// Assert
+	errWithCode, ok := err.(*errors.ErrorWithCode)
+	if !ok || errWithCode.Code != 400 {
+		t.Fatalf("Expected status code 400, got %v", err)
+	}
+}
+
@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+)
+
+const maxAPIKeyNameLength = 100
+
+type CreateAPIKeyUseCase struct {
+	userRepo   domain.UserRepository
+	apiKeyRepo domain.APIKeyRepository
+}
+
+func NewCreateAPIKeyUseCase(userRepo domain.UserRepository, apiKeyRepo domain.APIKeyRepository) *CreateAPIKeyUseCase {
+	return &CreateAPIKeyUseCase{
+		userRepo:   userRepo,
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+// CreateAPIKeyRequest crea una key para UserID, que puede ser el propio
+// usuario o, desde administración, una cuenta de servicio. ExpiresInDays 0
+// crea una key sin expiración.
+type CreateAPIKeyRequest struct {
+	UserID        string   `json:"-"`
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`
+	ExpiresInDays int      `json:"expires_in_days"`
+}
+
+type APIKeyDTO struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreateAPIKeyResponse lleva la key en claro; no vuelve a mostrarse.
+type CreateAPIKeyResponse struct {
+	APIKey *APIKeyDTO `json:"api_key"`
+	Key    string     `json:"key"`
+}
+
+func toAPIKeyDTO(key *domain.APIKey) *APIKeyDTO {
+	return &APIKeyDTO{
+		ID:         key.ID.String(),
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.ScopeList(),
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
+
+// Execute solo admite scopes que el dueño de la key ya tiene por sus roles:
+// una key nunca da más acceso que su usuario.
+func (uc *CreateAPIKeyUseCase) Execute(ctx context.Context, req CreateAPIKeyRequest) (*CreateAPIKeyResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > maxAPIKeyNameLength {
+		return nil, errors.NewErrorWithCode(400, "El nombre de la API key es requerido y admite hasta 100 caracteres", nil)
+	}
+
+	if req.ExpiresInDays < 0 {
+		return nil, errors.NewErrorWithCode(400, "La expiración debe ser un número de días positivo", nil)
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, req.UserID)
+	if err != nil {
+		return nil, errors.NewErrorWithCode(404, "Usuario no encontrado", errors.ErrUserNotFound)
+	}
+
+	scopes, err := grantableScopes(user, req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	var expiresAt *time.Time
+	if req.ExpiresInDays > 0 {
+		expiry := time.Now().Add(time.Duration(req.ExpiresInDays) * 24 * time.Hour)
+		expiresAt = &expiry
+	}
+
+	raw, key, err := domain.NewAPIKey(user.ID, name, scopes, expiresAt)
+	if err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al generar API key", err)
+	}
+
+	if err := uc.apiKeyRepo.Create(ctx, key); err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al guardar API key", err)
+	}
+
+	return &CreateAPIKeyResponse{
+		APIKey: toAPIKeyDTO(key),
+		Key:    raw,
+	}, nil
+}
+
+// grantableScopes devuelve los scopes pedidos sin duplicados, o 400 si alguno
+// no lo otorgan los roles del usuario.
+func grantableScopes(user *domain.User, requested []string) ([]string, error) {
+	allowed := make(map[string]bool)
+	for _, permission := range domain.PermissionsForRoles(user.Roles()) {
+		allowed[permission] = true
+	}
+
+	seen := make(map[string]bool)
+	scopes := []string{}
+	for _, scope := range requested {
+		if !allowed[scope] {
+			return nil, errors.NewErrorWithCode(400, "Scope no permitido para el usuario: "+scope, errors.ErrForbidden)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes, nil
+}
+
@@ -0,0 +1,95 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"user-service/internal/domain"
+	"user-service/internal/usecase"
+	"github.com/google/uuid"
+)
+
+func newRoleUser(role string) (*domain.User, *mockUserRepository) {
+	user := &domain.User{
+		ID:     uuid.New(),
+		Email:  role + "@example.com",
+		Name:   "Service Account",
+		Role:   role,
+		Status: domain.UserStatusActive,
+	}
+	return user, &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+}
+
+func TestCreateAPIKeyUseCase_Execute_Success(t *testing.T) {
+	// Arrange
+	user, userRepo := newRoleUser(domain.RoleAdmin)
+	apiKeyRepo := newMockAPIKeyRepository()
+
+	useCase := usecase.NewCreateAPIKeyUseCase(userRepo, apiKeyRepo)
+
+	// Act
+	response, err := useCase.Execute(context.Background(), usecase.CreateAPIKeyRequest{
+		UserID:        user.ID.String(),
+		Name:          "  reportes  ",
+		Scopes:        []string{domain.PermissionUsersRead, domain.PermissionUsersRead},
+		ExpiresInDays: 30,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !strings.HasPrefix(response.Key, domain.APIKeyPrefix) || !strings.HasPrefix(response.Key, response.APIKey.Prefix) {
+		t.Errorf("Expected key %q to start with prefix %q", response.Key, response.APIKey.Prefix)
+	}
+
+	stored := apiKeyRepo.keys[response.APIKey.ID]
+	if stored == nil || stored.KeyHash != domain.HashToken(response.Key) {
+		t.Fatal("Expected only the key hash to be stored")
+	}
+
+	if stored.Name != "reportes" || stored.Scopes != domain.PermissionUsersRead || stored.ExpiresAt == nil {
+		t.Errorf("Unexpected stored key %+v", stored)
+	}
+}
+
+func TestCreateAPIKeyUseCase_Execute_ScopeNotGrantedToUser(t *testing.T) {
+	// Arrange
+	user, userRepo := newRoleUser(domain.RoleSupport)
+	apiKeyRepo := newMockAPIKeyRepository()
+
+	useCase := usecase.NewCreateAPIKeyUseCase(userRepo, apiKeyRepo)
+
+	// Act
+	_, err := useCase.Execute(context.Background(), usecase.CreateAPIKeyRequest{
+		UserID: user.ID.String(),
+		Name:   "limpieza",
+		Scopes: []string{domain.PermissionUsersRead, domain.PermissionUsersDelete},
+	})
+
+	// Assert
+	assertLoginCode(t, err, 400)
+
+	if len(apiKeyRepo.keys) != 0 {
+		t.Error("Expected no key to be created")
+	}
+}
+
+func TestCreateAPIKeyUseCase_Execute_EmptyName(t *testing.T) {
+	// Arrange
+	user, userRepo := newRoleUser(domain.RoleUser)
+
+	useCase := usecase.NewCreateAPIKeyUseCase(userRepo, newMockAPIKeyRepository())
+
+	// Act
+	_, err := useCase.Execute(context.Background(), usecase.CreateAPIKeyRequest{
+		UserID: user.ID.String(),
+		Name:   "   ",
+	})
+
+	// Assert
+	assertLoginCode(t, err, 400)
+}
+
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+)
+
+const maxOAuthClientNameLength = 100
+
+type CreateOAuthClientUseCase struct {
+	clientRepo domain.OAuthClientRepository
+}
+
+func NewCreateOAuthClientUseCase(clientRepo domain.OAuthClientRepository) *CreateOAuthClientUseCase {
+	return &CreateOAuthClientUseCase{
+		clientRepo: clientRepo,
+	}
+}
+
+type CreateOAuthClientRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+type OAuthClientDTO struct {
+	ClientID   string     `json:"client_id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreateOAuthClientResponse lleva el secreto en claro; no vuelve a mostrarse.
+type CreateOAuthClientResponse struct {
+	Client       *OAuthClientDTO `json:"client"`
+	ClientSecret string          `json:"client_secret"`
+}
+
+func toOAuthClientDTO(client *domain.OAuthClient) *OAuthClientDTO {
+	return &OAuthClientDTO{
+		ClientID:   client.ID.String(),
+		Name:       client.Name,
+		Scopes:     client.ScopeList(),
+		DisabledAt: client.DisabledAt,
+		CreatedAt:  client.CreatedAt,
+	}
+}
+
+// Execute solo admite como scopes los permisos que definen los roles, que son
+// los que entiende RequirePermission.
+func (uc *CreateOAuthClientUseCase) Execute(ctx context.Context, req CreateOAuthClientRequest) (*CreateOAuthClientResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > maxOAuthClientNameLength {
+		return nil, errors.NewErrorWithCode(400, "El nombre del cliente es requerido y admite hasta 100 caracteres", nil)
+	}
+
+	seen := make(map[string]bool)
+	scopes := []string{}
+	for _, scope := range req.Scopes {
+		if !domain.IsValidPermission(scope) {
+			return nil, errors.NewErrorWithCode(400, "Scope desconocido: "+scope, errors.ErrInvalidScope)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+
+	secret, client, err := domain.NewOAuthClient(name, scopes)
+	if err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al generar secreto de cliente", err)
+	}
+
+	if err := uc.clientRepo.Create(ctx, client); err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al guardar cliente", err)
+	}
+
+	return &CreateOAuthClientResponse{
+		Client:       toOAuthClientDTO(client),
+		ClientSecret: secret,
+	}, nil
+}
+
@@ -0,0 +1,82 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"user-service/internal/domain"
+	"user-service/internal/usecase"
+	"user-service/pkg/errors"
+)
+
+func TestCreateOAuthClientUseCase_Execute_Success(t *testing.T) {
+	// Arrange
+	clientRepo := newMockOAuthClientRepository()
+
+	useCase := usecase.NewCreateOAuthClientUseCase(clientRepo)
+
+	// Act
+	response, err := useCase.Execute(context.Background(), usecase.CreateOAuthClientRequest{
+		Name:   "  facturación  ",
+		Scopes: []string{domain.PermissionUsersRead, domain.PermissionUsersRead},
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	stored := clientRepo.clients[response.Client.ClientID]
+	if stored == nil || stored.SecretHash != domain.HashToken(response.ClientSecret) {
+		t.Fatal("Expected only the secret hash to be stored")
+	}
+
+	if stored.Name != "facturación" || stored.Scopes != domain.PermissionUsersRead {
+		t.Errorf("Unexpected stored client %+v", stored)
+	}
+}
+
+func TestCreateOAuthClientUseCase_Execute_UnknownScope(t *testing.T) {
+	// Arrange
+	clientRepo := newMockOAuthClientRepository()
+
+	useCase := usecase.NewCreateOAuthClientUseCase(clientRepo)
+
+	// Act
+	_, err := useCase.Execute(context.Background(), usecase.CreateOAuthClientRequest{
+		Name:   "facturación",
+		Scopes: []string{domain.PermissionUsersRead, "users:everything"},
+	})
+
+	// Assert
+	assertOAuthError(t, err, 400, errors.ErrInvalidScope)
+
+	if len(clientRepo.clients) != 0 {
+		t.Error("Expected no client to be created")
+	}
+}
+
+func TestDisableOAuthClientUseCase_Execute(t *testing.T) {
+	// Arrange
+	clientRepo := newMockOAuthClientRepository()
+	secret, client := seedOAuthClient(t, clientRepo, domain.PermissionUsersRead)
+
+	useCase := usecase.NewDisableOAuthClientUseCase(clientRepo)
+	tokenUseCase := usecase.NewClientCredentialsUseCase(clientRepo, &mockJWTService{}, 0)
+
+	// Act
+	err := useCase.Execute(context.Background(), client.ID.String())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	_, err = tokenUseCase.Execute(context.Background(), usecase.ClientCredentialsRequest{
+		GrantType:    usecase.GrantTypeClientCredentials,
+		ClientID:     client.ID.String(),
+		ClientSecret: secret,
+	})
+	assertOAuthError(t, err, 401, errors.ErrInvalidClient)
+}
+
@@ -7,53 +7,118 @@ import (
 
 	"user-service/internal/domain"
 	"user-service/pkg/errors"
+
+	"github.com/google/uuid"
 )
 
 type CreateUserUseCase struct {
-	userRepo      domain.UserRepository
-	pldService    domain.PLDService
-	eventPublisher domain.EventPublisher
-	jwtService    domain.JWTService
+	userRepo           domain.UserRepository
+	refreshTokenRepo   domain.RefreshTokenRepository
+	sessionRepo        domain.SessionRepository
+	oneTimeTokenRepo   domain.OneTimeTokenRepository
+	pldService         domain.PLDService
+	notifier           domain.Notifier
+	jwtService         domain.JWTService
+	passwordHasher     domain.PasswordHasher
+	passwordValidator  *PasswordValidator
+	passwordHistory    *PasswordHistory
+	verificationPolicy EmailVerificationPolicy
+	concealment        SignupConcealment
+}
+
+// SignupConcealment hace que el alta no responda 409 para emails ya
+// registrados: todas las altas devuelven la misma respuesta pendiente y el
+// dueño de la cuenta existente recibe un aviso. Un alta nueva hace más trabajo
+// que una de un email existente, así que, desde que ambas se separan, las dos
+// tardan al menos MinDuration; debe superar lo que tarda en guardarse un alta.
+type SignupConcealment struct {
+	Enabled     bool
+	MinDuration time.Duration
 }
 
 func NewCreateUserUseCase(
 	userRepo domain.UserRepository,
+	refreshTokenRepo domain.RefreshTokenRepository,
+	sessionRepo domain.SessionRepository,
+	oneTimeTokenRepo domain.OneTimeTokenRepository,
 	pldService domain.PLDService,
-	eventPublisher domain.EventPublisher,
+	notifier domain.Notifier,
 	jwtService domain.JWTService,
+	passwordHasher domain.PasswordHasher,
+	passwordValidator *PasswordValidator,
+	passwordHistory *PasswordHistory,
+	verificationPolicy EmailVerificationPolicy,
+	concealment SignupConcealment,
 ) *CreateUserUseCase {
 	return &CreateUserUseCase{
-		userRepo:      userRepo,
-		pldService:    pldService,
-		eventPublisher: eventPublisher,
-		jwtService:    jwtService,
+		userRepo:           userRepo,
+		refreshTokenRepo:   refreshTokenRepo,
+		sessionRepo:        sessionRepo,
+		oneTimeTokenRepo:   oneTimeTokenRepo,
+		pldService:         pldService,
+		notifier:           notifier,
+		jwtService:         jwtService,
+		passwordHasher:     passwordHasher,
+		passwordValidator:  passwordValidator,
+		passwordHistory:    passwordHistory,
+		verificationPolicy: verificationPolicy,
+		concealment:        concealment,
 	}
 }
 
 type CreateUserRequest struct {
-	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=8"`
-	Name     string `json:"name" validate:"required"`
+	Email     string `json:"email" validate:"required,email"`
+	Password  string `json:"password" validate:"required,min=8"`
+	Name      string `json:"name" validate:"required"`
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
 }
 
+// CreateUserResponse no lleva tokens cuando la política exige verificar el
+// email antes del primer login. Con SignupConcealment solo lleva Message, sea
+// el email nuevo o no.
 type CreateUserResponse struct {
-	User  *UserDTO `json:"user"`
-	Token string   `json:"token"`
+	User         *UserDTO `json:"user,omitempty"`
+	Token        string   `json:"token,omitempty"`
+	RefreshToken string   `json:"refresh_token,omitempty"`
+	Message      string   `json:"message,omitempty"`
 }
 
+const signupPendingMessage = "Revise su correo para continuar con el registro"
+
 type UserDTO struct {
-	ID        string    `json:"id"`
-	Email     string    `json:"email"`
-	Name      string    `json:"name"`
-	CreatedAt time.Time `json:"created_at"`
+	ID            string    `json:"id"`
+	Email         string    `json:"email"`
+	Name          string    `json:"name"`
+	Role          string    `json:"role"`
+	Status        string    `json:"status"`
+	EmailVerified bool      `json:"email_verified"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func toUserDTO(user *domain.User) *UserDTO {
+	return &UserDTO{
+		ID:            user.ID.String(),
+		Email:         user.Email,
+		Name:          user.Name,
+		Role:          user.Role,
+		Status:        user.Status,
+		EmailVerified: user.IsEmailVerified(),
+		CreatedAt:     user.CreatedAt,
+	}
 }
 
 func (uc *CreateUserUseCase) Execute(ctx context.Context, req CreateUserRequest) (*CreateUserResponse, error) {
 	existingUser, err := uc.userRepo.FindByEmail(ctx, req.Email)
-	if err == nil && existingUser != nil {
+	exists := err == nil && existingUser != nil
+	if exists && !uc.concealment.Enabled {
 		return nil, errors.NewErrorWithCode(409, "El usuario ya existe", errors.ErrUserAlreadyExists)
 	}
 
+	if err := uc.passwordValidator.Validate(ctx, req.Password, req.Email, req.Name); err != nil {
+		return nil, err
+	}
+
 	firstName, lastName := splitName(req.Name)
 	inBlacklist, err := uc.pldService.CheckBlacklist(ctx, firstName, lastName, req.Email)
 	if err != nil {
@@ -63,47 +128,99 @@ func (uc *CreateUserUseCase) Execute(ctx context.Context, req CreateUserRequest)
 		return nil, errors.NewErrorWithCode(403, "Usuario en lista negra", errors.ErrUserInBlacklist)
 	}
 
+	if uc.concealment.Enabled {
+		defer waitUntil(ctx, time.Now().Add(uc.concealment.MinDuration))
+	}
+
+	if exists {
+		return uc.concealExistingAccount(existingUser, req.Password)
+	}
+
+	// ID y CreatedAt se fijan aquí porque el evento del outbox los necesita
+	// antes de insertar.
+	now := time.Now()
 	user := &domain.User{
-		Email: req.Email,
-		Name:  req.Name,
+		ID:     uuid.New(),
+		Email:  req.Email,
+		Name:   req.Name,
+		Role:   domain.RoleUser,
+		Status: domain.UserStatusActive,
 	}
 
-	if err := user.HashPassword(req.Password); err != nil {
+	if err := user.HashPassword(uc.passwordHasher, req.Password); err != nil {
 		return nil, errors.NewErrorWithCode(500, "Error al procesar contraseña", err)
 	}
+	user.CreatedAt = now
+	user.PasswordChangedAt = &now
 
 	if err := user.Validate(); err != nil {
 		return nil, errors.NewErrorWithCode(400, "Datos inválidos", err)
 	}
 
-	if err := uc.userRepo.Create(ctx, user); err != nil {
+	message, err := domain.NewUserCreatedMessage(ctx, user)
+	if err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al crear usuario", err)
+	}
+
+	if err := uc.userRepo.CreateWithOutbox(ctx, user, message); err != nil {
 		return nil, errors.NewErrorWithCode(500, "Error al crear usuario", err)
 	}
 
-	token, err := uc.jwtService.GenerateToken(user.ID.String())
+	if err := uc.passwordHistory.Record(ctx, user); err != nil {
+		return nil, err
+	}
+
+	ttl := time.Duration(uc.verificationPolicy.TokenTTLMinutes) * time.Minute
+	if err := sendEmailVerification(ctx, uc.oneTimeTokenRepo, uc.notifier, user, ttl); err != nil {
+		return nil, err
+	}
+
+	if uc.concealment.Enabled {
+		return &CreateUserResponse{Message: signupPendingMessage}, nil
+	}
+
+	if uc.verificationPolicy.Required {
+		return &CreateUserResponse{User: toUserDTO(user)}, nil
+	}
+
+	client := SessionClient{UserAgent: req.UserAgent, IPAddress: req.IPAddress}
+	tokens, err := startSession(ctx, uc.jwtService, uc.refreshTokenRepo, uc.sessionRepo, user, client)
 	if err != nil {
-		return nil, errors.NewErrorWithCode(500, "Error al generar token", err)
+		return nil, err
+	}
+
+	return &CreateUserResponse{
+		User:         toUserDTO(user),
+		Token:        tokens.accessToken,
+		RefreshToken: tokens.refreshToken,
+	}, nil
+}
+
+// concealExistingAccount responde como un alta nueva: calcula un hash
+// descartable y avisa al dueño de la cuenta. El resto del tiempo del alta lo
+// iguala SignupConcealment.MinDuration.
+func (uc *CreateUserUseCase) concealExistingAccount(existingUser *domain.User, password string) (*CreateUserResponse, error) {
+	if _, err := uc.passwordHasher.Hash(password); err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al procesar contraseña", err)
 	}
 
 	go func() {
-		eventCtx := context.Background()
-		uc.eventPublisher.PublishUserCreated(
-			eventCtx,
-			user.ID.String(),
-			user.Email,
-			user.CreatedAt.Unix(),
-		)
+		notifyCtx := context.Background()
+		uc.notifier.SendAccountExists(notifyCtx, existingUser.Email)
 	}()
 
-	return &CreateUserResponse{
-		User: &UserDTO{
-			ID:        user.ID.String(),
-			Email:     user.Email,
-			Name:      user.Name,
-			CreatedAt: user.CreatedAt,
-		},
-		Token: token,
-	}, nil
+	return &CreateUserResponse{Message: signupPendingMessage}, nil
+}
+
+// waitUntil espera hasta deadline, salvo que se cancele ctx.
+func waitUntil(ctx context.Context, deadline time.Time) {
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
 }
 
 func splitName(name string) (firstName, lastName string) {
@@ -118,4 +235,3 @@ func splitName(name string) (firstName, lastName string) {
 	lastName = strings.Join(parts[1:], " ")
 	return firstName, lastName
 }
-
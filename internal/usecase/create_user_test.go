@@ -3,15 +3,20 @@ package usecase_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"user-service/internal/domain"
 	"user-service/internal/usecase"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
 )
 
 // Mocks
 type mockUserRepository struct {
-	users map[string]*domain.User
+	users  map[string]*domain.User
+	outbox []*domain.OutboxMessage
 }
 
 func (m *mockUserRepository) Create(ctx context.Context, user *domain.User) error {
@@ -22,6 +27,14 @@ func (m *mockUserRepository) Create(ctx context.Context, user *domain.User) erro
 	return nil
 }
 
+func (m *mockUserRepository) CreateWithOutbox(ctx context.Context, user *domain.User, message *domain.OutboxMessage) error {
+	if err := m.Create(ctx, user); err != nil {
+		return err
+	}
+	m.outbox = append(m.outbox, message)
+	return nil
+}
+
 func (m *mockUserRepository) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
 	user, exists := m.users[email]
 	if !exists {
@@ -39,6 +52,52 @@ func (m *mockUserRepository) FindByID(ctx context.Context, id string) (*domain.U
 	return nil, errors.New("usuario no encontrado")
 }
 
+func (m *mockUserRepository) List(ctx context.Context, filter domain.UserFilter) ([]*domain.User, int64, error) {
+	var matched []*domain.User
+	for _, user := range m.users {
+		if filter.Status != "" && user.Status != filter.Status {
+			continue
+		}
+		if filter.Email != "" && !strings.Contains(user.Email, filter.Email) {
+			continue
+		}
+		matched = append(matched, user)
+	}
+
+	start := (filter.Page - 1) * filter.PageSize
+	if start > len(matched) {
+		start = len(matched)
+	}
+	end := start + filter.PageSize
+	if end > len(matched) {
+		end = len(matched)
+	}
+	return matched[start:end], int64(len(matched)), nil
+}
+
+func (m *mockUserRepository) Update(ctx context.Context, user *domain.User, columns ...string) error {
+	if len(columns) == 0 {
+		return errors.New("no se indicaron columnas")
+	}
+	for key, existing := range m.users {
+		if existing.ID == user.ID {
+			m.users[key] = user
+			return nil
+		}
+	}
+	return errors.New("usuario no encontrado")
+}
+
+func (m *mockUserRepository) Delete(ctx context.Context, id string) error {
+	for key, existing := range m.users {
+		if existing.ID.String() == id {
+			delete(m.users, key)
+			return nil
+		}
+	}
+	return errors.New("usuario no encontrado")
+}
+
 type mockPLDService struct {
 	blacklist map[string]bool
 }
@@ -49,32 +108,555 @@ func (m *mockPLDService) CheckBlacklist(ctx context.Context, firstName, lastName
 
 type mockEventPublisher struct{}
 
+func (m *mockEventPublisher) Publish(ctx context.Context, eventType string, body []byte) error {
+	return nil
+}
+
 func (m *mockEventPublisher) PublishUserCreated(ctx context.Context, userID, email string, createdAt int64) error {
 	return nil
 }
 
-type mockJWTService struct{}
+func (m *mockEventPublisher) PublishUserUpdated(ctx context.Context, userID, email string, updatedAt int64) error {
+	return nil
+}
+
+func (m *mockEventPublisher) PublishPasswordChanged(ctx context.Context, userID string, changedAt int64) error {
+	return nil
+}
+
+func (m *mockEventPublisher) PublishUserLocked(ctx context.Context, userID, email string, lockedUntil int64) error {
+	return nil
+}
+
+func (m *mockEventPublisher) Close(ctx context.Context) error {
+	return nil
+}
+
+type mockJWTService struct {
+	issued []domain.Principal
+}
 
-func (m *mockJWTService) GenerateToken(userID string) (string, error) {
+func (m *mockJWTService) GenerateToken(principal domain.Principal) (string, error) {
+	m.issued = append(m.issued, principal)
 	return "mock-token", nil
 }
 
-func (m *mockJWTService) ValidateToken(tokenString string) (string, error) {
-	return "mock-user-id", nil
+func (m *mockJWTService) ValidateToken(tokenString string) (*domain.Principal, error) {
+	return &domain.Principal{UserID: "mock-user-id", TokenID: "mock-token-id"}, nil
+}
+
+func (m *mockJWTService) GenerateRefreshToken() (string, time.Time, error) {
+	return uuid.NewString(), time.Now().Add(time.Hour), nil
+}
+
+func (m *mockJWTService) PublicKeys() []domain.JSONWebKey {
+	return nil
+}
+
+func (m *mockJWTService) SigningAlgorithm() string {
+	return "HS256"
+}
+
+type mockRefreshTokenRepository struct {
+	tokens map[string]*domain.RefreshToken
+}
+
+func newMockRefreshTokenRepository() *mockRefreshTokenRepository {
+	return &mockRefreshTokenRepository{tokens: make(map[string]*domain.RefreshToken)}
+}
+
+func (m *mockRefreshTokenRepository) Create(ctx context.Context, token *domain.RefreshToken) error {
+	token.ID = uuid.New()
+	m.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (m *mockRefreshTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	token, exists := m.tokens[tokenHash]
+	if !exists {
+		return nil, errors.New("refresh token no encontrado")
+	}
+	return token, nil
+}
+
+func (m *mockRefreshTokenRepository) MarkUsed(ctx context.Context, id string) (bool, error) {
+	for _, token := range m.tokens {
+		if token.ID.String() == id && token.UsedAt == nil && token.RevokedAt == nil {
+			now := time.Now()
+			token.UsedAt = &now
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *mockRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	now := time.Now()
+	for _, token := range m.tokens {
+		if token.FamilyID.String() == familyID && token.RevokedAt == nil {
+			token.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (m *mockRefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID string) error {
+	now := time.Now()
+	for _, token := range m.tokens {
+		if token.UserID.String() == userID && token.RevokedAt == nil {
+			token.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (m *mockRefreshTokenRepository) RevokeOtherFamilies(ctx context.Context, userID, keepFamilyID string) error {
+	now := time.Now()
+	for _, token := range m.tokens {
+		if token.UserID.String() == userID && token.FamilyID.String() != keepFamilyID && token.RevokedAt == nil {
+			token.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+type mockRevocationStore struct {
+	revokedTokens   map[string]bool
+	revokedBefore   map[string]time.Time
+	revokedSessions map[string]bool
+}
+
+func newMockRevocationStore() *mockRevocationStore {
+	return &mockRevocationStore{
+		revokedTokens:   make(map[string]bool),
+		revokedBefore:   make(map[string]time.Time),
+		revokedSessions: make(map[string]bool),
+	}
+}
+
+func (m *mockRevocationStore) RevokeToken(ctx context.Context, principal *domain.Principal) error {
+	m.revokedTokens[principal.TokenID] = true
+	return nil
+}
+
+func (m *mockRevocationStore) RevokeAllForUser(ctx context.Context, userID string, revokedBefore time.Time) error {
+	m.revokedBefore[userID] = revokedBefore
+	return nil
+}
+
+func (m *mockRevocationStore) RevokeSession(ctx context.Context, sessionID string) error {
+	m.revokedSessions[sessionID] = true
+	return nil
+}
+
+func (m *mockRevocationStore) IsRevoked(ctx context.Context, principal *domain.Principal) (bool, error) {
+	if before, ok := m.revokedBefore[principal.UserID]; ok && !principal.IssuedAt.After(before) {
+		return true, nil
+	}
+	if m.revokedSessions[principal.SessionID] {
+		return true, nil
+	}
+	return m.revokedTokens[principal.TokenID], nil
+}
+
+type mockAPIKeyRepository struct {
+	keys map[string]*domain.APIKey
+}
+
+func newMockAPIKeyRepository() *mockAPIKeyRepository {
+	return &mockAPIKeyRepository{keys: make(map[string]*domain.APIKey)}
+}
+
+func (m *mockAPIKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	key.ID = uuid.New()
+	key.CreatedAt = time.Now()
+	m.keys[key.ID.String()] = key
+	return nil
+}
+
+func (m *mockAPIKeyRepository) FindByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	for _, key := range m.keys {
+		if key.KeyHash == keyHash {
+			return key, nil
+		}
+	}
+	return nil, errors.New("API key no encontrada")
+}
+
+func (m *mockAPIKeyRepository) FindByID(ctx context.Context, id string) (*domain.APIKey, error) {
+	key, exists := m.keys[id]
+	if !exists {
+		return nil, errors.New("API key no encontrada")
+	}
+	return key, nil
+}
+
+func (m *mockAPIKeyRepository) ListByUser(ctx context.Context, userID string) ([]*domain.APIKey, error) {
+	var keys []*domain.APIKey
+	for _, key := range m.keys {
+		if key.UserID.String() == userID && key.RevokedAt == nil {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (m *mockAPIKeyRepository) Revoke(ctx context.Context, id string) error {
+	if key, exists := m.keys[id]; exists && key.RevokedAt == nil {
+		now := time.Now()
+		key.RevokedAt = &now
+	}
+	return nil
+}
+
+func (m *mockAPIKeyRepository) MarkUsed(ctx context.Context, id string, usedAt time.Time) error {
+	if key, exists := m.keys[id]; exists {
+		key.LastUsedAt = &usedAt
+	}
+	return nil
+}
+
+type mockOAuthClientRepository struct {
+	clients map[string]*domain.OAuthClient
+}
+
+func newMockOAuthClientRepository() *mockOAuthClientRepository {
+	return &mockOAuthClientRepository{clients: make(map[string]*domain.OAuthClient)}
+}
+
+func (m *mockOAuthClientRepository) Create(ctx context.Context, client *domain.OAuthClient) error {
+	client.ID = uuid.New()
+	client.CreatedAt = time.Now()
+	m.clients[client.ID.String()] = client
+	return nil
+}
+
+func (m *mockOAuthClientRepository) FindByID(ctx context.Context, id string) (*domain.OAuthClient, error) {
+	client, exists := m.clients[id]
+	if !exists {
+		return nil, errors.New("cliente no encontrado")
+	}
+	return client, nil
+}
+
+func (m *mockOAuthClientRepository) List(ctx context.Context) ([]*domain.OAuthClient, error) {
+	var clients []*domain.OAuthClient
+	for _, client := range m.clients {
+		clients = append(clients, client)
+	}
+	return clients, nil
+}
+
+func (m *mockOAuthClientRepository) Disable(ctx context.Context, id string) error {
+	if client, exists := m.clients[id]; exists && client.DisabledAt == nil {
+		now := time.Now()
+		client.DisabledAt = &now
+	}
+	return nil
+}
+
+type mockSessionRepository struct {
+	sessions map[string]*domain.Session
+}
+
+func newMockSessionRepository() *mockSessionRepository {
+	return &mockSessionRepository{sessions: make(map[string]*domain.Session)}
+}
+
+func (m *mockSessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	m.sessions[session.ID.String()] = session
+	return nil
+}
+
+func (m *mockSessionRepository) FindByID(ctx context.Context, id string) (*domain.Session, error) {
+	session, exists := m.sessions[id]
+	if !exists {
+		return nil, errors.New("sesión no encontrada")
+	}
+	return session, nil
+}
+
+func (m *mockSessionRepository) ListActive(ctx context.Context, userID string) ([]*domain.Session, error) {
+	var sessions []*domain.Session
+	for _, session := range m.sessions {
+		if session.UserID.String() == userID && session.RevokedAt == nil {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions, nil
+}
+
+func (m *mockSessionRepository) Touch(ctx context.Context, id, ipAddress string, seenAt time.Time) error {
+	if session, exists := m.sessions[id]; exists {
+		session.LastSeenAt = seenAt
+		if ipAddress != "" {
+			session.IPAddress = ipAddress
+		}
+	}
+	return nil
+}
+
+type mockOneTimeTokenRepository struct {
+	tokens map[string]*domain.OneTimeToken
+}
+
+func newMockOneTimeTokenRepository() *mockOneTimeTokenRepository {
+	return &mockOneTimeTokenRepository{tokens: make(map[string]*domain.OneTimeToken)}
+}
+
+func (m *mockOneTimeTokenRepository) Create(ctx context.Context, token *domain.OneTimeToken) error {
+	token.ID = uuid.New()
+	m.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (m *mockOneTimeTokenRepository) FindByHash(ctx context.Context, purpose, tokenHash string) (*domain.OneTimeToken, error) {
+	token, exists := m.tokens[tokenHash]
+	if !exists || token.Purpose != purpose {
+		return nil, errors.New("token no encontrado")
+	}
+	return token, nil
+}
+
+func (m *mockOneTimeTokenRepository) MarkUsed(ctx context.Context, id string) (bool, error) {
+	for _, token := range m.tokens {
+		if token.ID.String() == id && token.UsedAt == nil && !token.IsExpired(time.Now()) {
+			now := time.Now()
+			token.UsedAt = &now
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *mockOneTimeTokenRepository) InvalidateForUser(ctx context.Context, userID, purpose string) error {
+	now := time.Now()
+	for _, token := range m.tokens {
+		if token.UserID.String() == userID && token.Purpose == purpose && token.UsedAt == nil {
+			token.UsedAt = &now
+		}
+	}
+	return nil
+}
+
+type mockLoginAttemptRepository struct {
+	attempts map[string]*domain.LoginAttempt
+}
+
+func newMockLoginAttemptRepository() *mockLoginAttemptRepository {
+	return &mockLoginAttemptRepository{attempts: make(map[string]*domain.LoginAttempt)}
+}
+
+func (m *mockLoginAttemptRepository) Find(ctx context.Context, key string) (*domain.LoginAttempt, error) {
+	if attempt, exists := m.attempts[key]; exists {
+		copied := *attempt
+		return &copied, nil
+	}
+	return &domain.LoginAttempt{Key: key}, nil
+}
+
+func (m *mockLoginAttemptRepository) RecordFailure(ctx context.Context, key string) (*domain.LoginAttempt, error) {
+	attempt, exists := m.attempts[key]
+	if !exists {
+		attempt = &domain.LoginAttempt{Key: key}
+		m.attempts[key] = attempt
+	}
+	attempt.Failures++
+	attempt.LastFailureAt = time.Now()
+	copied := *attempt
+	return &copied, nil
+}
+
+func (m *mockLoginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	if attempt, exists := m.attempts[key]; exists {
+		attempt.LockedUntil = &until
+	}
+	return nil
+}
+
+func (m *mockLoginAttemptRepository) Release(ctx context.Context, key string) error {
+	if attempt, exists := m.attempts[key]; exists && attempt.Failures > 0 {
+		attempt.Failures--
+	}
+	return nil
+}
+
+func (m *mockLoginAttemptRepository) Reset(ctx context.Context, key string) error {
+	delete(m.attempts, key)
+	return nil
+}
+
+// mockNotifier publica en un canal el token de cada mensaje; los casos de uso
+// notifican en una goroutine, así que los tests deben leer del canal.
+type mockNotifier struct {
+	sent          chan string
+	verifications chan string
+	existing      chan string
+}
+
+func newMockNotifier() *mockNotifier {
+	return &mockNotifier{
+		sent:          make(chan string, 10),
+		verifications: make(chan string, 10),
+		existing:      make(chan string, 10),
+	}
+}
+
+func (m *mockNotifier) SendPasswordReset(ctx context.Context, email, token string, expiresAt time.Time) error {
+	m.sent <- token
+	return nil
+}
+
+func (m *mockNotifier) SendEmailVerification(ctx context.Context, email, token string, expiresAt time.Time) error {
+	m.verifications <- token
+	return nil
+}
+
+func (m *mockNotifier) SendAccountExists(ctx context.Context, email string) error {
+	m.existing <- email
+	return nil
+}
+
+type mockRecoveryCodeRepository struct {
+	codes map[string]*domain.RecoveryCode
+}
+
+func newMockRecoveryCodeRepository() *mockRecoveryCodeRepository {
+	return &mockRecoveryCodeRepository{codes: make(map[string]*domain.RecoveryCode)}
+}
+
+func (m *mockRecoveryCodeRepository) ReplaceForUser(ctx context.Context, userID string, codes []*domain.RecoveryCode) error {
+	for hash, code := range m.codes {
+		if code.UserID.String() == userID {
+			delete(m.codes, hash)
+		}
+	}
+	for _, code := range codes {
+		m.codes[code.CodeHash] = code
+	}
+	return nil
+}
+
+func (m *mockRecoveryCodeRepository) Consume(ctx context.Context, userID, codeHash string) (bool, error) {
+	code, exists := m.codes[codeHash]
+	if !exists || code.UserID.String() != userID || code.UsedAt != nil {
+		return false, nil
+	}
+	now := time.Now()
+	code.UsedAt = &now
+	return true, nil
+}
+
+// mockCipher marca el texto en lugar de cifrarlo, para que los tests puedan
+// comprobar que no se guarda el secreto en claro.
+type mockCipher struct{}
+
+func (m *mockCipher) Encrypt(plaintext string) (string, error) {
+	return "enc:" + plaintext, nil
+}
+
+func (m *mockCipher) Decrypt(ciphertext string) (string, error) {
+	if !strings.HasPrefix(ciphertext, "enc:") {
+		return "", errors.New("texto cifrado inválido")
+	}
+	return strings.TrimPrefix(ciphertext, "enc:"), nil
+}
+
+// mockPasswordHasher usa bcrypt con el coste mínimo para que los tests sean
+// rápidos; los hashes con otro coste se consideran desactualizados.
+type mockPasswordHasher struct{}
+
+func (m *mockPasswordHasher) Hash(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	return string(hashed), err
+}
+
+func (m *mockPasswordHasher) Verify(password, encodedHash string) (bool, error) {
+	return bcrypt.CompareHashAndPassword([]byte(encodedHash), []byte(password)) == nil, nil
+}
+
+func (m *mockPasswordHasher) NeedsRehash(encodedHash string) bool {
+	cost, err := bcrypt.Cost([]byte(encodedHash))
+	return err != nil || cost != bcrypt.MinCost
+}
+
+type mockPasswordHistoryRepository struct {
+	entries []*domain.PasswordHistoryEntry
+}
+
+func newMockPasswordHistoryRepository() *mockPasswordHistoryRepository {
+	return &mockPasswordHistoryRepository{}
+}
+
+func (m *mockPasswordHistoryRepository) Add(ctx context.Context, entry *domain.PasswordHistoryEntry) error {
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+func (m *mockPasswordHistoryRepository) ListRecent(ctx context.Context, userID string, limit int) ([]*domain.PasswordHistoryEntry, error) {
+	var recent []*domain.PasswordHistoryEntry
+	for i := len(m.entries) - 1; i >= 0 && len(recent) < limit; i-- {
+		if m.entries[i].UserID.String() == userID {
+			recent = append(recent, m.entries[i])
+		}
+	}
+	return recent, nil
+}
+
+func (m *mockPasswordHistoryRepository) Prune(ctx context.Context, userID string, keep int) error {
+	var kept []*domain.PasswordHistoryEntry
+	count := 0
+	for i := len(m.entries) - 1; i >= 0; i-- {
+		entry := m.entries[i]
+		if entry.UserID.String() == userID {
+			if count >= keep {
+				continue
+			}
+			count++
+		}
+		kept = append([]*domain.PasswordHistoryEntry{entry}, kept...)
+	}
+	m.entries = kept
+	return nil
+}
+
+// mockBreachedPasswordChecker marca como filtradas las contraseñas del mapa.
+type mockBreachedPasswordChecker struct {
+	breached map[string]bool
+}
+
+func (m *mockBreachedPasswordChecker) IsBreached(ctx context.Context, password string) (bool, error) {
+	return m.breached[password], nil
+}
+
+func newPasswordValidator() *usecase.PasswordValidator {
+	return usecase.NewPasswordValidator(domain.DefaultPasswordPolicy(), nil)
+}
+
+// newPasswordHistory devuelve un historial desactivado.
+func newPasswordHistory() *usecase.PasswordHistory {
+	return usecase.NewPasswordHistory(newMockPasswordHistoryRepository(), &mockPasswordHasher{}, 0)
 }
 
 func TestCreateUserUseCase_Execute_Success(t *testing.T) {
 	// Arrange
 	userRepo := &mockUserRepository{users: make(map[string]*domain.User)}
 	pldService := &mockPLDService{blacklist: make(map[string]bool)}
-	eventPublisher := &mockEventPublisher{}
 	jwtService := &mockJWTService{}
 
 	useCase := usecase.NewCreateUserUseCase(
 		userRepo,
+		newMockRefreshTokenRepository(),
+		newMockSessionRepository(),
+		newMockOneTimeTokenRepository(),
 		pldService,
-		eventPublisher,
+		newMockNotifier(),
 		jwtService,
+		&mockPasswordHasher{},
+		newPasswordValidator(),
+		newPasswordHistory(),
+		usecase.EmailVerificationPolicy{TokenTTLMinutes: 60},
+		usecase.SignupConcealment{},
 	)
 
 	req := usecase.CreateUserRequest{
@@ -102,6 +684,70 @@ func TestCreateUserUseCase_Execute_Success(t *testing.T) {
 	if response.Token == "" {
 		t.Error("Expected token, got empty string")
 	}
+
+	if response.RefreshToken == "" {
+		t.Error("Expected refresh token, got empty string")
+	}
+}
+
+func TestCreateUserUseCase_Execute_WritesUserCreatedToOutbox(t *testing.T) {
+	// Arrange
+	userRepo := &mockUserRepository{users: make(map[string]*domain.User)}
+
+	useCase := usecase.NewCreateUserUseCase(
+		userRepo,
+		newMockRefreshTokenRepository(),
+		newMockSessionRepository(),
+		newMockOneTimeTokenRepository(),
+		&mockPLDService{blacklist: make(map[string]bool)},
+		newMockNotifier(),
+		&mockJWTService{},
+		&mockPasswordHasher{},
+		newPasswordValidator(),
+		newPasswordHistory(),
+		usecase.EmailVerificationPolicy{TokenTTLMinutes: 60},
+		usecase.SignupConcealment{},
+	)
+
+	// Act
+	ctx := domain.WithCorrelationID(context.Background(), "req-outbox")
+	response, err := useCase.Execute(ctx, usecase.CreateUserRequest{
+		Email:    "outbox@example.com",
+		Password: "password123",
+		Name:     "Gustavo Hernández",
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(userRepo.outbox) != 1 {
+		t.Fatalf("Expected one outbox message, got %d", len(userRepo.outbox))
+	}
+
+	message := userRepo.outbox[0]
+	if message.EventType != domain.EventUserCreated {
+		t.Errorf("Expected event %s, got %s", domain.EventUserCreated, message.EventType)
+	}
+
+	event, err := domain.DecodeEvent(message.Payload, "")
+	if err != nil {
+		t.Fatalf("Expected an event envelope, got %v", err)
+	}
+
+	if event.Type != domain.EventUserCreated || event.CorrelationID != "req-outbox" {
+		t.Errorf("Unexpected envelope %+v", event)
+	}
+
+	var payload domain.UserCreatedPayload
+	if err := event.DecodePayload(&payload); err != nil {
+		t.Fatalf("Expected a user.created payload, got %v", err)
+	}
+
+	if payload.UserID != response.User.ID || payload.Email != "outbox@example.com" {
+		t.Errorf("Unexpected payload %+v", payload)
+	}
 }
 
 func TestCreateUserUseCase_Execute_UserInBlacklist(t *testing.T) {
@@ -112,14 +758,21 @@ func TestCreateUserUseCase_Execute_UserInBlacklist(t *testing.T) {
 			"blacklisted@example.com": true,
 		},
 	}
-	eventPublisher := &mockEventPublisher{}
 	jwtService := &mockJWTService{}
 
 	useCase := usecase.NewCreateUserUseCase(
 		userRepo,
+		newMockRefreshTokenRepository(),
+		newMockSessionRepository(),
+		newMockOneTimeTokenRepository(),
 		pldService,
-		eventPublisher,
+		newMockNotifier(),
 		jwtService,
+		&mockPasswordHasher{},
+		newPasswordValidator(),
+		newPasswordHistory(),
+		usecase.EmailVerificationPolicy{TokenTTLMinutes: 60},
+		usecase.SignupConcealment{},
 	)
 
 	req := usecase.CreateUserRequest{
@@ -147,7 +800,7 @@ func TestCreateUserUseCase_Execute_UserAlreadyExists(t *testing.T) {
 		Email: "existing@example.com",
 		Name:  "Existing User",
 	}
-	existingUser.HashPassword("password123")
+	existingUser.HashPassword(&mockPasswordHasher{}, "password123")
 
 	userRepo := &mockUserRepository{
 		users: map[string]*domain.User{
@@ -155,14 +808,21 @@ func TestCreateUserUseCase_Execute_UserAlreadyExists(t *testing.T) {
 		},
 	}
 	pldService := &mockPLDService{blacklist: make(map[string]bool)}
-	eventPublisher := &mockEventPublisher{}
 	jwtService := &mockJWTService{}
 
 	useCase := usecase.NewCreateUserUseCase(
 		userRepo,
+		newMockRefreshTokenRepository(),
+		newMockSessionRepository(),
+		newMockOneTimeTokenRepository(),
 		pldService,
-		eventPublisher,
+		newMockNotifier(),
 		jwtService,
+		&mockPasswordHasher{},
+		newPasswordValidator(),
+		newPasswordHistory(),
+		usecase.EmailVerificationPolicy{TokenTTLMinutes: 60},
+		usecase.SignupConcealment{},
 	)
 
 	req := usecase.CreateUserRequest{
@@ -188,14 +848,21 @@ func TestCreateUserUseCase_Execute_InvalidPassword(t *testing.T) {
 	// Arrange
 	userRepo := &mockUserRepository{users: make(map[string]*domain.User)}
 	pldService := &mockPLDService{blacklist: make(map[string]bool)}
-	eventPublisher := &mockEventPublisher{}
 	jwtService := &mockJWTService{}
 
 	useCase := usecase.NewCreateUserUseCase(
 		userRepo,
+		newMockRefreshTokenRepository(),
+		newMockSessionRepository(),
+		newMockOneTimeTokenRepository(),
 		pldService,
-		eventPublisher,
+		newMockNotifier(),
 		jwtService,
+		&mockPasswordHasher{},
+		newPasswordValidator(),
+		newPasswordHistory(),
+		usecase.EmailVerificationPolicy{TokenTTLMinutes: 60},
+		usecase.SignupConcealment{},
 	)
 
 	req := usecase.CreateUserRequest{
@@ -216,3 +883,184 @@ func TestCreateUserUseCase_Execute_InvalidPassword(t *testing.T) {
 	}
 }
 
+func TestCreateUserUseCase_Execute_VerificationRequired(t *testing.T) {
+	// Arrange
+	userRepo := &mockUserRepository{users: make(map[string]*domain.User)}
+	pldService := &mockPLDService{blacklist: make(map[string]bool)}
+	notifier := newMockNotifier()
+	jwtService := &mockJWTService{}
+
+	useCase := usecase.NewCreateUserUseCase(
+		userRepo,
+		newMockRefreshTokenRepository(),
+		newMockSessionRepository(),
+		newMockOneTimeTokenRepository(),
+		pldService,
+		notifier,
+		jwtService,
+		&mockPasswordHasher{},
+		newPasswordValidator(),
+		newPasswordHistory(),
+		usecase.EmailVerificationPolicy{Required: true, TokenTTLMinutes: 60},
+		usecase.SignupConcealment{},
+	)
+
+	req := usecase.CreateUserRequest{
+		Email:    "test@example.com",
+		Password: "password123",
+		Name:     "Gustavo Hernández",
+	}
+
+	// Act
+	response, err := useCase.Execute(context.Background(), req)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if response.Token != "" || response.RefreshToken != "" {
+		t.Error("Expected no tokens before email verification")
+	}
+
+	if response.User.EmailVerified {
+		t.Error("Expected email to be unverified")
+	}
+
+	select {
+	case <-notifier.verifications:
+	case <-time.After(time.Second):
+		t.Fatal("Expected verification email to be sent")
+	}
+}
+
+func TestCreateUserUseCase_Execute_ConcealExisting(t *testing.T) {
+	// Arrange
+	existingUser := &domain.User{
+		ID:    uuid.New(),
+		Email: "existing@example.com",
+		Name:  "Existing User",
+	}
+	existingUser.HashPassword(&mockPasswordHasher{}, "password123")
+
+	userRepo := &mockUserRepository{
+		users: map[string]*domain.User{
+			"existing@example.com": existingUser,
+		},
+	}
+	notifier := newMockNotifier()
+
+	useCase := usecase.NewCreateUserUseCase(
+		userRepo,
+		newMockRefreshTokenRepository(),
+		newMockSessionRepository(),
+		newMockOneTimeTokenRepository(),
+		&mockPLDService{blacklist: make(map[string]bool)},
+		notifier,
+		&mockJWTService{},
+		&mockPasswordHasher{},
+		newPasswordValidator(),
+		newPasswordHistory(),
+		usecase.EmailVerificationPolicy{TokenTTLMinutes: 60},
+		usecase.SignupConcealment{Enabled: true},
+	)
+
+	// Act
+	existing, existingErr := useCase.Execute(context.Background(), usecase.CreateUserRequest{
+		Email:    "existing@example.com",
+		Password: "password123",
+		Name:     "New User",
+	})
+	created, createdErr := useCase.Execute(context.Background(), usecase.CreateUserRequest{
+		Email:    "new@example.com",
+		Password: "password123",
+		Name:     "New User",
+	})
+
+	// Assert
+	if existingErr != nil || createdErr != nil {
+		t.Fatalf("Expected no errors, got %v and %v", existingErr, createdErr)
+	}
+
+	if *existing != *created {
+		t.Errorf("Expected identical responses, got %+v and %+v", existing, created)
+	}
+
+	if existing.User != nil || existing.Token != "" {
+		t.Error("Expected no user or tokens in concealed response")
+	}
+
+	if _, exists := userRepo.users["new@example.com"]; !exists {
+		t.Error("Expected new user to be created")
+	}
+
+	if existingUser.Name != "Existing User" {
+		t.Error("Expected existing user to remain unchanged")
+	}
+
+	select {
+	case email := <-notifier.existing:
+		if email != "existing@example.com" {
+			t.Errorf("Expected notice to existing@example.com, got %s", email)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected existing owner to be notified")
+	}
+}
+
+func TestCreateUserUseCase_Execute_ConcealExistingMinDuration(t *testing.T) {
+	// Arrange
+	existingUser := &domain.User{
+		ID:    uuid.New(),
+		Email: "existing@example.com",
+		Name:  "Existing User",
+	}
+	existingUser.HashPassword(&mockPasswordHasher{}, "password123")
+
+	userRepo := &mockUserRepository{
+		users: map[string]*domain.User{
+			"existing@example.com": existingUser,
+		},
+	}
+	concealment := usecase.SignupConcealment{Enabled: true, MinDuration: 50 * time.Millisecond}
+
+	useCase := usecase.NewCreateUserUseCase(
+		userRepo,
+		newMockRefreshTokenRepository(),
+		newMockSessionRepository(),
+		newMockOneTimeTokenRepository(),
+		&mockPLDService{blacklist: make(map[string]bool)},
+		newMockNotifier(),
+		&mockJWTService{},
+		&mockPasswordHasher{},
+		newPasswordValidator(),
+		newPasswordHistory(),
+		usecase.EmailVerificationPolicy{TokenTTLMinutes: 60},
+		concealment,
+	)
+
+	for _, email := range []string{"existing@example.com", "new@example.com"} {
+		// Act
+		start := time.Now()
+		response, err := useCase.Execute(context.Background(), usecase.CreateUserRequest{
+			Email:    email,
+			Password: "password123",
+			Name:     "New User",
+		})
+		elapsed := time.Since(start)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error for %s, got %v", email, err)
+		}
+
+		if *response != (usecase.CreateUserResponse{Message: "Revise su correo para continuar con el registro"}) {
+			t.Errorf("Unexpected response for %s: %+v", email, response)
+		}
+
+		if elapsed < concealment.MinDuration {
+			t.Errorf("Expected %s to take at least %v, took %v", email, concealment.MinDuration, elapsed)
+		}
+	}
+}
+
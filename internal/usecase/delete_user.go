@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+)
+
+type DeleteUserUseCase struct {
+	userRepo         domain.UserRepository
+	refreshTokenRepo domain.RefreshTokenRepository
+	revocationStore  domain.TokenRevocationStore
+}
+
+func NewDeleteUserUseCase(
+	userRepo domain.UserRepository,
+	refreshTokenRepo domain.RefreshTokenRepository,
+	revocationStore domain.TokenRevocationStore,
+) *DeleteUserUseCase {
+	return &DeleteUserUseCase{
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		revocationStore:  revocationStore,
+	}
+}
+
+type DeleteUserRequest struct {
+	ActorID string `json:"-"`
+	UserID  string `json:"-"`
+}
+
+func (uc *DeleteUserUseCase) Execute(ctx context.Context, req DeleteUserRequest) error {
+	if req.ActorID == req.UserID {
+		return errors.NewErrorWithCode(400, "No puede eliminar su propio usuario", errors.ErrForbidden)
+	}
+
+	if _, err := uc.userRepo.FindByID(ctx, req.UserID); err != nil {
+		return errors.NewErrorWithCode(404, "Usuario no encontrado", errors.ErrUserNotFound)
+	}
+
+	if err := revokeUserSessions(ctx, uc.refreshTokenRepo, uc.revocationStore, req.UserID); err != nil {
+		return err
+	}
+
+	if err := uc.userRepo.Delete(ctx, req.UserID); err != nil {
+		return errors.NewErrorWithCode(500, "Error al eliminar usuario", err)
+	}
+
+	return nil
+}
+
@@ -0,0 +1,64 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/usecase"
+	"github.com/google/uuid"
+)
+
+func TestDeleteUserUseCase_Execute_Success(t *testing.T) {
+	// Arrange
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com", Status: domain.UserStatusActive}
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+	refreshRepo := newMockRefreshTokenRepository()
+	stored := seedRefreshTokenForUser(refreshRepo, "refresh-1", user.ID, time.Now().Add(time.Hour))
+
+	useCase := usecase.NewDeleteUserUseCase(userRepo, refreshRepo, newMockRevocationStore())
+
+	// Act
+	err := useCase.Execute(context.Background(), usecase.DeleteUserRequest{
+		ActorID: uuid.NewString(),
+		UserID:  user.ID.String(),
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, exists := userRepo.users[user.Email]; exists {
+		t.Error("Expected user to be deleted")
+	}
+
+	if stored.RevokedAt == nil {
+		t.Error("Expected refresh tokens to be revoked")
+	}
+}
+
+func TestDeleteUserUseCase_Execute_CannotDeleteSelf(t *testing.T) {
+	// Arrange
+	user := &domain.User{ID: uuid.New(), Email: "admin@example.com"}
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+
+	useCase := usecase.NewDeleteUserUseCase(userRepo, newMockRefreshTokenRepository(), newMockRevocationStore())
+
+	// Act
+	err := useCase.Execute(context.Background(), usecase.DeleteUserRequest{
+		ActorID: user.ID.String(),
+		UserID:  user.ID.String(),
+	})
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected error when deleting own user, got nil")
+	}
+
+	if _, exists := userRepo.users[user.Email]; !exists {
+		t.Error("Expected user not to be deleted")
+	}
+}
+
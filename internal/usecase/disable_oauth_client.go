@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+)
+
+type DisableOAuthClientUseCase struct {
+	clientRepo domain.OAuthClientRepository
+}
+
+func NewDisableOAuthClientUseCase(clientRepo domain.OAuthClientRepository) *DisableOAuthClientUseCase {
+	return &DisableOAuthClientUseCase{
+		clientRepo: clientRepo,
+	}
+}
+
+// Execute impide que el cliente obtenga tokens nuevos. Los ya emitidos siguen
+// valiendo hasta su expiración (JWT_ACCESS_EXPIRES_IN).
+func (uc *DisableOAuthClientUseCase) Execute(ctx context.Context, clientID string) error {
+	if _, err := uc.clientRepo.FindByID(ctx, clientID); err != nil {
+		return errors.NewErrorWithCode(404, "Cliente no encontrado", errors.ErrOAuthClientNotFound)
+	}
+
+	if err := uc.clientRepo.Disable(ctx, clientID); err != nil {
+		return errors.NewErrorWithCode(500, "Error al desactivar cliente", err)
+	}
+
+	return nil
+}
+
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+	"user-service/pkg/totp"
+)
+
+type EnrollTOTPUseCase struct {
+	userRepo domain.UserRepository
+	cipher   domain.SecretCipher
+	issuer   string
+}
+
+func NewEnrollTOTPUseCase(
+	userRepo domain.UserRepository,
+	cipher domain.SecretCipher,
+	issuer string,
+) *EnrollTOTPUseCase {
+	return &EnrollTOTPUseCase{
+		userRepo: userRepo,
+		cipher:   cipher,
+		issuer:   issuer,
+	}
+}
+
+type EnrollTOTPResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// Execute genera un secreto nuevo y lo guarda cifrado. MFA no se activa hasta
+// confirmar un código, así que repetir el enrolamiento reemplaza el secreto
+// pendiente.
+func (uc *EnrollTOTPUseCase) Execute(ctx context.Context, userID string) (*EnrollTOTPResponse, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, errors.NewErrorWithCode(404, "Usuario no encontrado", errors.ErrUserNotFound)
+	}
+
+	if user.MFAEnabled {
+		return nil, errors.NewErrorWithCode(409, "MFA ya está activado", errors.ErrMFAAlreadyEnabled)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al generar secreto", err)
+	}
+
+	encrypted, err := uc.cipher.Encrypt(secret)
+	if err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al cifrar secreto", err)
+	}
+
+	user.TOTPSecret = encrypted
+	user.TOTPLastStep = 0
+	if err := uc.userRepo.Update(ctx, user, "totp_secret", "totp_last_step"); err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al guardar secreto", err)
+	}
+
+	return &EnrollTOTPResponse{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(uc.issuer, user.Email, secret),
+	}, nil
+}
+
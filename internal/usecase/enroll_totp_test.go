@@ -0,0 +1,67 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"user-service/internal/domain"
+	"user-service/internal/usecase"
+	"user-service/pkg/errors"
+)
+
+func TestEnrollTOTPUseCase_Execute_Success(t *testing.T) {
+	// Arrange
+	user := newPasswordUser(t, "password123")
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+
+	useCase := usecase.NewEnrollTOTPUseCase(userRepo, &mockCipher{}, "Crabi")
+
+	// Act
+	response, err := useCase.Execute(context.Background(), user.ID.String())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if response.Secret == "" {
+		t.Fatal("Expected secret, got empty string")
+	}
+
+	if !strings.HasPrefix(response.ProvisioningURI, "otpauth://totp/") {
+		t.Errorf("Expected otpauth URI, got %s", response.ProvisioningURI)
+	}
+
+	if user.TOTPSecret != "enc:"+response.Secret {
+		t.Errorf("Expected secret stored encrypted, got %s", user.TOTPSecret)
+	}
+
+	if user.MFAEnabled {
+		t.Error("Expected MFA to stay disabled until confirmed")
+	}
+}
+
+func TestEnrollTOTPUseCase_Execute_AlreadyEnabled(t *testing.T) {
+	// Arrange
+	user := newPasswordUser(t, "password123")
+	user.MFAEnabled = true
+	user.TOTPSecret = "enc:EXISTING"
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+
+	useCase := usecase.NewEnrollTOTPUseCase(userRepo, &mockCipher{}, "Crabi")
+
+	// Act
+	_, err := useCase.Execute(context.Background(), user.ID.String())
+
+	// Assert
+	errWithCode, ok := err.(*errors.ErrorWithCode)
+	if !ok || errWithCode.Code != 409 {
+		t.Fatalf("Expected status code 409, got %v", err)
+	}
+
+	if user.TOTPSecret != "enc:EXISTING" {
+		t.Error("Expected existing secret to be kept")
+	}
+}
+
@@ -0,0 +1,14 @@
+package usecase
+
+import (
+	"context"
+
+	"user-service/internal/domain"
+)
+
+// eventContext es el contexto con el que se publican los eventos en segundo
+// plano: no se cancela al terminar la petición pero conserva su correlation ID.
+func eventContext(ctx context.Context) context.Context {
+	return domain.WithCorrelationID(context.Background(), domain.CorrelationIDFromContext(ctx))
+}
+
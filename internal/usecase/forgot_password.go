@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+)
+
+type ForgotPasswordUseCase struct {
+	userRepo         domain.UserRepository
+	oneTimeTokenRepo domain.OneTimeTokenRepository
+	notifier         domain.Notifier
+	resetTokenTTL    time.Duration
+}
+
+func NewForgotPasswordUseCase(
+	userRepo domain.UserRepository,
+	oneTimeTokenRepo domain.OneTimeTokenRepository,
+	notifier domain.Notifier,
+	resetTokenTTLMinutes int,
+) *ForgotPasswordUseCase {
+	return &ForgotPasswordUseCase{
+		userRepo:         userRepo,
+		oneTimeTokenRepo: oneTimeTokenRepo,
+		notifier:         notifier,
+		resetTokenTTL:    time.Duration(resetTokenTTLMinutes) * time.Minute,
+	}
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+// Execute no informa si el email existe: para un email desconocido o una cuenta
+// suspendida termina sin error y sin enviar nada.
+func (uc *ForgotPasswordUseCase) Execute(ctx context.Context, req ForgotPasswordRequest) error {
+	user, err := uc.userRepo.FindByEmail(ctx, req.Email)
+	if err != nil || user.IsSuspended() {
+		return nil
+	}
+
+	rawToken, token, err := domain.NewOneTimeToken(user.ID, domain.TokenPurposePasswordReset, uc.resetTokenTTL)
+	if err != nil {
+		return errors.NewErrorWithCode(500, "Error al generar token", err)
+	}
+
+	if err := uc.oneTimeTokenRepo.Create(ctx, token); err != nil {
+		return errors.NewErrorWithCode(500, "Error al guardar token", err)
+	}
+
+	go func() {
+		notifyCtx := context.Background()
+		uc.notifier.SendPasswordReset(notifyCtx, user.Email, rawToken, token.ExpiresAt)
+	}()
+
+	return nil
+}
+
@@ -0,0 +1,71 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/usecase"
+	"github.com/google/uuid"
+)
+
+func TestForgotPasswordUseCase_Execute_SendsToken(t *testing.T) {
+	// Arrange
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com", Status: domain.UserStatusActive}
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+	tokenRepo := newMockOneTimeTokenRepository()
+	notifier := newMockNotifier()
+
+	useCase := usecase.NewForgotPasswordUseCase(userRepo, tokenRepo, notifier, 30)
+
+	// Act
+	err := useCase.Execute(context.Background(), usecase.ForgotPasswordRequest{Email: user.Email})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var raw string
+	select {
+	case raw = <-notifier.sent:
+	case <-time.After(time.Second):
+		t.Fatal("Expected reset token to be sent")
+	}
+
+	stored, exists := tokenRepo.tokens[domain.HashToken(raw)]
+	if !exists {
+		t.Fatal("Expected only the token hash to be stored")
+	}
+
+	if stored.Purpose != domain.TokenPurposePasswordReset {
+		t.Errorf("Expected purpose %s, got %s", domain.TokenPurposePasswordReset, stored.Purpose)
+	}
+
+	if stored.ExpiresAt.After(time.Now().Add(31 * time.Minute)) {
+		t.Errorf("Expected token to expire within 30 minutes, got %v", stored.ExpiresAt)
+	}
+}
+
+func TestForgotPasswordUseCase_Execute_UnknownEmail(t *testing.T) {
+	// Arrange
+	userRepo := &mockUserRepository{users: make(map[string]*domain.User)}
+	tokenRepo := newMockOneTimeTokenRepository()
+	notifier := newMockNotifier()
+
+	useCase := usecase.NewForgotPasswordUseCase(userRepo, tokenRepo, notifier, 30)
+
+	// Act
+	err := useCase.Execute(context.Background(), usecase.ForgotPasswordRequest{Email: "nobody@example.com"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error for unknown email, got %v", err)
+	}
+
+	if len(tokenRepo.tokens) != 0 {
+		t.Errorf("Expected no tokens, got %d", len(tokenRepo.tokens))
+	}
+}
+
@@ -28,12 +28,7 @@ func (uc *GetUserUseCase) Execute(ctx context.Context, userID string) (*GetUserR
 	}
 
 	return &GetUserResponse{
-		User: &UserDTO{
-			ID:        user.ID.String(),
-			Email:     user.Email,
-			Name:      user.Name,
-			CreatedAt: user.CreatedAt,
-		},
+		User: toUserDTO(user),
 	}, nil
 }
 
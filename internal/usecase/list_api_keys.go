@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+)
+
+type ListAPIKeysUseCase struct {
+	apiKeyRepo domain.APIKeyRepository
+}
+
+func NewListAPIKeysUseCase(apiKeyRepo domain.APIKeyRepository) *ListAPIKeysUseCase {
+	return &ListAPIKeysUseCase{
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+type ListAPIKeysResponse struct {
+	APIKeys []*APIKeyDTO `json:"api_keys"`
+}
+
+func (uc *ListAPIKeysUseCase) Execute(ctx context.Context, userID string) (*ListAPIKeysResponse, error) {
+	keys, err := uc.apiKeyRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al listar API keys", err)
+	}
+
+	dtos := make([]*APIKeyDTO, 0, len(keys))
+	for _, key := range keys {
+		dtos = append(dtos, toAPIKeyDTO(key))
+	}
+
+	return &ListAPIKeysResponse{
+		APIKeys: dtos,
+	}, nil
+}
+
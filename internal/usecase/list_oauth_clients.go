@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+)
+
+type ListOAuthClientsUseCase struct {
+	clientRepo domain.OAuthClientRepository
+}
+
+func NewListOAuthClientsUseCase(clientRepo domain.OAuthClientRepository) *ListOAuthClientsUseCase {
+	return &ListOAuthClientsUseCase{
+		clientRepo: clientRepo,
+	}
+}
+
+type ListOAuthClientsResponse struct {
+	Clients []*OAuthClientDTO `json:"clients"`
+}
+
+func (uc *ListOAuthClientsUseCase) Execute(ctx context.Context) (*ListOAuthClientsResponse, error) {
+	clients, err := uc.clientRepo.List(ctx)
+	if err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al listar clientes", err)
+	}
+
+	dtos := make([]*OAuthClientDTO, 0, len(clients))
+	for _, client := range clients {
+		dtos = append(dtos, toOAuthClientDTO(client))
+	}
+
+	return &ListOAuthClientsResponse{
+		Clients: dtos,
+	}, nil
+}
+
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+)
+
+type ListSessionsUseCase struct {
+	sessionRepo domain.SessionRepository
+}
+
+func NewListSessionsUseCase(sessionRepo domain.SessionRepository) *ListSessionsUseCase {
+	return &ListSessionsUseCase{
+		sessionRepo: sessionRepo,
+	}
+}
+
+// SessionDTO describe una sesión abierta. Current marca la sesión del token
+// con el que se hace la petición.
+type SessionDTO struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	Current    bool      `json:"current"`
+}
+
+type ListSessionsResponse struct {
+	Sessions []*SessionDTO `json:"sessions"`
+}
+
+func (uc *ListSessionsUseCase) Execute(ctx context.Context, principal *domain.Principal) (*ListSessionsResponse, error) {
+	sessions, err := uc.sessionRepo.ListActive(ctx, principal.UserID)
+	if err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al listar sesiones", err)
+	}
+
+	dtos := make([]*SessionDTO, 0, len(sessions))
+	for _, session := range sessions {
+		dtos = append(dtos, &SessionDTO{
+			ID:         session.ID.String(),
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			Current:    session.ID.String() == principal.SessionID,
+		})
+	}
+
+	return &ListSessionsResponse{
+		Sessions: dtos,
+	}, nil
+}
+
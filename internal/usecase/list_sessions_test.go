@@ -0,0 +1,67 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/usecase"
+	"github.com/google/uuid"
+)
+
+func seedSession(repo *mockSessionRepository, userID uuid.UUID) *domain.Session {
+	now := time.Now()
+	session := &domain.Session{
+		ID:         uuid.New(),
+		UserID:     userID,
+		UserAgent:  "Mozilla/5.0",
+		IPAddress:  "203.0.113.7",
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}
+	repo.Create(context.Background(), session)
+	return session
+}
+
+func TestListSessionsUseCase_Execute(t *testing.T) {
+	// Arrange
+	userID := uuid.New()
+	sessionRepo := newMockSessionRepository()
+	current := seedSession(sessionRepo, userID)
+	other := seedSession(sessionRepo, userID)
+	seedSession(sessionRepo, uuid.New())
+
+	useCase := usecase.NewListSessionsUseCase(sessionRepo)
+
+	// Act
+	response, err := useCase.Execute(context.Background(), &domain.Principal{
+		UserID:    userID.String(),
+		SessionID: current.ID.String(),
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(response.Sessions) != 2 {
+		t.Fatalf("Expected 2 sessions, got %d", len(response.Sessions))
+	}
+
+	for _, session := range response.Sessions {
+		switch session.ID {
+		case current.ID.String():
+			if !session.Current {
+				t.Error("Expected current session to be marked")
+			}
+		case other.ID.String():
+			if session.Current {
+				t.Error("Expected other session not to be marked as current")
+			}
+		default:
+			t.Errorf("Unexpected session %s", session.ID)
+		}
+	}
+}
+
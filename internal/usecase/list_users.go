@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+type ListUsersUseCase struct {
+	userRepo domain.UserRepository
+}
+
+func NewListUsersUseCase(userRepo domain.UserRepository) *ListUsersUseCase {
+	return &ListUsersUseCase{
+		userRepo: userRepo,
+	}
+}
+
+type ListUsersRequest struct {
+	Email       string     `json:"email"`
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	CreatedFrom *time.Time `json:"created_from"`
+	CreatedTo   *time.Time `json:"created_to"`
+	Page        int        `json:"page"`
+	PageSize    int        `json:"page_size"`
+}
+
+type ListUsersResponse struct {
+	Users    []*UserDTO `json:"users"`
+	Total    int64      `json:"total"`
+	Page     int        `json:"page"`
+	PageSize int        `json:"page_size"`
+}
+
+func (uc *ListUsersUseCase) Execute(ctx context.Context, req ListUsersRequest) (*ListUsersResponse, error) {
+	if req.Status != "" && req.Status != domain.UserStatusActive && req.Status != domain.UserStatusSuspended {
+		return nil, errors.NewErrorWithCode(400, "Estado inválido", nil)
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	users, total, err := uc.userRepo.List(ctx, domain.UserFilter{
+		Email:       req.Email,
+		Name:        req.Name,
+		Status:      req.Status,
+		CreatedFrom: req.CreatedFrom,
+		CreatedTo:   req.CreatedTo,
+		Page:        page,
+		PageSize:    pageSize,
+	})
+	if err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al listar usuarios", err)
+	}
+
+	dtos := make([]*UserDTO, 0, len(users))
+	for _, user := range users {
+		dtos = append(dtos, toUserDTO(user))
+	}
+
+	return &ListUsersResponse{
+		Users:    dtos,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
@@ -0,0 +1,79 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"user-service/internal/domain"
+	"user-service/internal/usecase"
+	"github.com/google/uuid"
+)
+
+func seedUsers(count int, status string) *mockUserRepository {
+	userRepo := &mockUserRepository{users: make(map[string]*domain.User)}
+	for i := 0; i < count; i++ {
+		email := fmt.Sprintf("user%d@example.com", i)
+		userRepo.users[email] = &domain.User{
+			ID:     uuid.New(),
+			Email:  email,
+			Name:   fmt.Sprintf("User %d", i),
+			Role:   domain.RoleUser,
+			Status: status,
+		}
+	}
+	return userRepo
+}
+
+func TestListUsersUseCase_Execute_DefaultPagination(t *testing.T) {
+	// Arrange
+	userRepo := seedUsers(25, domain.UserStatusActive)
+	useCase := usecase.NewListUsersUseCase(userRepo)
+
+	// Act
+	response, err := useCase.Execute(context.Background(), usecase.ListUsersRequest{})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if response.Page != 1 || response.PageSize != 20 {
+		t.Errorf("Expected page 1 of size 20, got page %d of size %d", response.Page, response.PageSize)
+	}
+
+	if response.Total != 25 || len(response.Users) != 20 {
+		t.Errorf("Expected 20 of 25 users, got %d of %d", len(response.Users), response.Total)
+	}
+}
+
+func TestListUsersUseCase_Execute_CapsPageSize(t *testing.T) {
+	// Arrange
+	useCase := usecase.NewListUsersUseCase(seedUsers(3, domain.UserStatusActive))
+
+	// Act
+	response, err := useCase.Execute(context.Background(), usecase.ListUsersRequest{PageSize: 1000})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if response.PageSize != 100 {
+		t.Errorf("Expected page size capped at 100, got %d", response.PageSize)
+	}
+}
+
+func TestListUsersUseCase_Execute_InvalidStatus(t *testing.T) {
+	// Arrange
+	useCase := usecase.NewListUsersUseCase(seedUsers(3, domain.UserStatusActive))
+
+	// Act
+	_, err := useCase.Execute(context.Background(), usecase.ListUsersRequest{Status: "deleted"})
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected error for invalid status, got nil")
+	}
+}
+
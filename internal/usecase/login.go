@@ -2,52 +2,313 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"user-service/internal/domain"
 	"user-service/pkg/errors"
 )
 
 type LoginUseCase struct {
-	userRepo   domain.UserRepository
-	jwtService domain.JWTService
+	userRepo           domain.UserRepository
+	refreshTokenRepo   domain.RefreshTokenRepository
+	sessionRepo        domain.SessionRepository
+	oneTimeTokenRepo   domain.OneTimeTokenRepository
+	loginAttemptRepo   domain.LoginAttemptRepository
+	eventPublisher     domain.EventPublisher
+	jwtService         domain.JWTService
+	passwordHasher     domain.PasswordHasher
+	verificationPolicy EmailVerificationPolicy
+	accountLockout     domain.LockoutPolicy
+	ipLockout          domain.LockoutPolicy
+	rotationPolicy     domain.PasswordRotationPolicy
+	dummyPasswordHash  string
 }
 
 func NewLoginUseCase(
 	userRepo domain.UserRepository,
+	refreshTokenRepo domain.RefreshTokenRepository,
+	sessionRepo domain.SessionRepository,
+	oneTimeTokenRepo domain.OneTimeTokenRepository,
+	loginAttemptRepo domain.LoginAttemptRepository,
+	eventPublisher domain.EventPublisher,
 	jwtService domain.JWTService,
+	passwordHasher domain.PasswordHasher,
+	verificationPolicy EmailVerificationPolicy,
+	accountLockout domain.LockoutPolicy,
+	ipLockout domain.LockoutPolicy,
+	rotationPolicy domain.PasswordRotationPolicy,
 ) *LoginUseCase {
+	// dummyPasswordHash se compara cuando el email no existe, para que el login
+	// tarde lo mismo exista o no la cuenta. Usa el algoritmo configurado: las
+	// cuentas con un hash heredado de otro algoritmo tardan lo que cueste ese
+	// algoritmo hasta su próximo login correcto, que las migra. Ningún hash
+	// ficticio único iguala a la vez ambos grupos, así que se acepta esa
+	// diferencia durante la migración.
+	dummyPasswordHash, _ := passwordHasher.Hash("dummy-password-for-timing")
+
 	return &LoginUseCase{
-		userRepo:   userRepo,
-		jwtService: jwtService,
+		userRepo:           userRepo,
+		refreshTokenRepo:   refreshTokenRepo,
+		sessionRepo:        sessionRepo,
+		oneTimeTokenRepo:   oneTimeTokenRepo,
+		loginAttemptRepo:   loginAttemptRepo,
+		eventPublisher:     eventPublisher,
+		jwtService:         jwtService,
+		passwordHasher:     passwordHasher,
+		verificationPolicy: verificationPolicy,
+		accountLockout:     accountLockout,
+		ipLockout:          ipLockout,
+		rotationPolicy:     rotationPolicy,
+		dummyPasswordHash:  dummyPasswordHash,
 	}
 }
 
 type LoginRequest struct {
-	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required"`
+	Email     string `json:"email" validate:"required,email"`
+	Password  string `json:"password" validate:"required"`
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
 }
 
+// LoginResponse lleva los tokens de sesión o, si el usuario tiene MFA, solo
+// el token del desafío que debe canjearse en /auth/mfa/verify. Con la
+// contraseña expirada, Token es un token restringido al cambio de contraseña
+// y no hay refresh token.
 type LoginResponse struct {
-	Token string `json:"token"`
+	Token           string `json:"token,omitempty"`
+	RefreshToken    string `json:"refresh_token,omitempty"`
+	MFARequired     bool   `json:"mfa_required,omitempty"`
+	MFAToken        string `json:"mfa_token,omitempty"`
+	PasswordExpired bool   `json:"password_expired,omitempty"`
 }
 
 func (uc *LoginUseCase) Execute(ctx context.Context, req LoginRequest) (*LoginResponse, error) {
+	accountKey := domain.AccountAttemptKey(req.Email)
+	ipKey := ""
+	if req.IPAddress != "" {
+		ipKey = domain.IPAttemptKey(req.IPAddress)
+	}
+
+	if err := checkThrottle(ctx, uc.loginAttemptRepo, accountKey, uc.accountLockout); err != nil {
+		return nil, err
+	}
+	if ipKey != "" {
+		if err := checkThrottle(ctx, uc.loginAttemptRepo, ipKey, uc.ipLockout); err != nil {
+			return nil, err
+		}
+	}
+
+	var ipAttempt *domain.LoginAttempt
+	if ipKey != "" {
+		attempt, err := reserveAttempt(ctx, uc.loginAttemptRepo, ipKey, uc.ipLockout)
+		if err != nil {
+			return nil, err
+		}
+		ipAttempt = attempt
+	}
+	accountAttempt, err := reserveAttempt(ctx, uc.loginAttemptRepo, accountKey, uc.accountLockout)
+	if err != nil {
+		return nil, err
+	}
+
 	user, err := uc.userRepo.FindByEmail(ctx, req.Email)
 	if err != nil {
-		return nil, errors.NewErrorWithCode(401, "Credenciales inválidas", errors.ErrInvalidCredentials)
+		uc.passwordHasher.Verify(req.Password, uc.dummyPasswordHash)
+		return nil, uc.recordFailure(ctx, req, nil, accountAttempt, ipAttempt)
 	}
 
-	if !user.VerifyPassword(req.Password) {
-		return nil, errors.NewErrorWithCode(401, "Credenciales inválidas", errors.ErrInvalidCredentials)
+	if !user.VerifyPassword(uc.passwordHasher, req.Password) {
+		return nil, uc.recordFailure(ctx, req, user, accountAttempt, ipAttempt)
 	}
 
-	token, err := uc.jwtService.GenerateToken(user.ID.String())
+	if err := uc.loginAttemptRepo.Reset(ctx, accountKey); err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al registrar login", err)
+	}
+	// La IP no se reinicia con un login correcto, para que una cuenta válida no
+	// sirva para seguir probando otras desde la misma IP: solo se devuelve la
+	// reserva de este intento.
+	if ipKey != "" {
+		if err := uc.loginAttemptRepo.Release(ctx, ipKey); err != nil {
+			return nil, errors.NewErrorWithCode(500, "Error al registrar login", err)
+		}
+	}
+
+	uc.rehashIfNeeded(ctx, user, req.Password)
+
+	if user.IsSuspended() {
+		return nil, errors.NewErrorWithCode(403, "Usuario suspendido", errors.ErrUserSuspended)
+	}
+
+	if uc.verificationPolicy.Required && !user.IsEmailVerified() {
+		return nil, errors.NewErrorWithCode(403, "Email no verificado", errors.ErrEmailNotVerified)
+	}
+
+	if user.MFAEnabled {
+		return uc.startMFAChallenge(ctx, user)
+	}
+
+	client := SessionClient{UserAgent: req.UserAgent, IPAddress: req.IPAddress}
+	return completeLogin(ctx, uc.jwtService, uc.refreshTokenRepo, uc.sessionRepo, uc.rotationPolicy, user, client)
+}
+
+// completeLogin abre una sesión nueva, salvo que la contraseña haya expirado: en ese
+// caso solo emite un token restringido al cambio de contraseña. Con MFA se
+// evalúa después del segundo factor.
+func completeLogin(
+	ctx context.Context,
+	jwtService domain.JWTService,
+	refreshTokenRepo domain.RefreshTokenRepository,
+	sessionRepo domain.SessionRepository,
+	rotationPolicy domain.PasswordRotationPolicy,
+	user *domain.User,
+	client SessionClient,
+) (*LoginResponse, error) {
+	if rotationPolicy.IsExpired(user, time.Now()) {
+		restrictedToken, err := issueRestrictedToken(jwtService, user)
+		if err != nil {
+			return nil, err
+		}
+		return &LoginResponse{
+			Token:           restrictedToken,
+			PasswordExpired: true,
+		}, nil
+	}
+
+	tokens, err := startSession(ctx, jwtService, refreshTokenRepo, sessionRepo, user, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginResponse{
+		Token:        tokens.accessToken,
+		RefreshToken: tokens.refreshToken,
+	}, nil
+}
+
+// checkThrottle rechaza el intento si la clave está bloqueada o si aún no pasó
+// el retraso exigido desde el último fallo. En el login se evalúa antes de
+// buscar al usuario, así que la respuesta es la misma exista o no el email.
+func checkThrottle(ctx context.Context, loginAttemptRepo domain.LoginAttemptRepository, key string, policy domain.LockoutPolicy) error {
+	attempt, err := loginAttemptRepo.Find(ctx, key)
 	if err != nil {
-		return nil, errors.NewErrorWithCode(500, "Error al generar token", err)
+		return errors.NewErrorWithCode(500, "Error al verificar intentos de login", err)
+	}
+
+	now := time.Now()
+	if attempt.IsStale(now, policy) {
+		if err := loginAttemptRepo.Reset(ctx, key); err != nil {
+			return errors.NewErrorWithCode(500, "Error al verificar intentos de login", err)
+		}
+		return nil
+	}
+
+	if now.Before(attempt.RetryAt(policy)) {
+		return errors.NewErrorWithCode(429, "Demasiados intentos, intente más tarde", errors.ErrTooManyAttempts)
+	}
+
+	return nil
+}
+
+// recordFailure bloquea las claves cuyo intento reservado alcanzó el límite y
+// devuelve el error de credenciales. El fallo ya se contó al reservar. user es
+// nil cuando el email no existe; en ese caso también se bloquea, pero no hay
+// evento que publicar. ipAttempt es nil si no se conoce la IP.
+func (uc *LoginUseCase) recordFailure(ctx context.Context, req LoginRequest, user *domain.User, accountAttempt, ipAttempt *domain.LoginAttempt) error {
+	lockedUntil, err := lockIfExhausted(ctx, uc.loginAttemptRepo, accountAttempt, uc.accountLockout)
+	if err != nil {
+		return err
+	}
+
+	if ipAttempt != nil {
+		if _, err := lockIfExhausted(ctx, uc.loginAttemptRepo, ipAttempt, uc.ipLockout); err != nil {
+			return err
+		}
+	}
+
+	if user != nil && !lockedUntil.IsZero() {
+		go func() {
+			eventCtx := eventContext(ctx)
+			uc.eventPublisher.PublishUserLocked(
+				eventCtx,
+				user.ID.String(),
+				user.Email,
+				lockedUntil.Unix(),
+			)
+		}()
+	}
+
+	return errors.NewErrorWithCode(401, "Credenciales inválidas", errors.ErrInvalidCredentials)
+}
+
+// rehashIfNeeded actualiza el hash al algoritmo y costes configurados. Es el
+// único momento en que se dispone de la contraseña en claro; si falla, el login
+// sigue y se reintenta en el siguiente.
+func (uc *LoginUseCase) rehashIfNeeded(ctx context.Context, user *domain.User, password string) {
+	if !uc.passwordHasher.NeedsRehash(user.Password) {
+		return
+	}
+
+	previous := user.Password
+	if err := user.HashPassword(uc.passwordHasher, password); err != nil {
+		return
+	}
+	if err := uc.userRepo.Update(ctx, user, "password"); err != nil {
+		user.Password = previous
+	}
+}
+
+// reserveAttempt cuenta el intento como fallo antes de verificar la
+// credencial. El incremento es atómico, así que en una ráfaga de peticiones
+// concurrentes, que pasan todas checkThrottle, solo MaxFailures llegan a
+// verificarse; el resto se rechaza y deja la clave bloqueada. Un intento
+// correcto reinicia o libera la reserva.
+func reserveAttempt(ctx context.Context, loginAttemptRepo domain.LoginAttemptRepository, key string, policy domain.LockoutPolicy) (*domain.LoginAttempt, error) {
+	attempt, err := loginAttemptRepo.RecordFailure(ctx, key)
+	if err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al registrar intento de login", err)
+	}
+
+	if policy.MaxFailures <= 0 || attempt.Failures <= policy.MaxFailures {
+		return attempt, nil
+	}
+
+	if attempt.LockedUntil == nil {
+		if err := loginAttemptRepo.Lock(ctx, key, time.Now().Add(policy.LockoutDuration)); err != nil {
+			return nil, errors.NewErrorWithCode(500, "Error al bloquear cuenta", err)
+		}
+	}
+
+	return nil, errors.NewErrorWithCode(429, "Demasiados intentos, intente más tarde", errors.ErrTooManyAttempts)
+}
+
+// lockIfExhausted bloquea la clave si el intento reservado, ya fallido, era el
+// último permitido, y devuelve el fin del bloqueo si lo provocó.
+func lockIfExhausted(ctx context.Context, loginAttemptRepo domain.LoginAttemptRepository, attempt *domain.LoginAttempt, policy domain.LockoutPolicy) (time.Time, error) {
+	if policy.MaxFailures <= 0 || attempt.Failures < policy.MaxFailures || attempt.LockedUntil != nil {
+		return time.Time{}, nil
+	}
+
+	lockedUntil := time.Now().Add(policy.LockoutDuration)
+	if err := loginAttemptRepo.Lock(ctx, attempt.Key, lockedUntil); err != nil {
+		return time.Time{}, errors.NewErrorWithCode(500, "Error al bloquear cuenta", err)
+	}
+
+	return lockedUntil, nil
+}
+
+func (uc *LoginUseCase) startMFAChallenge(ctx context.Context, user *domain.User) (*LoginResponse, error) {
+	rawToken, token, err := domain.NewOneTimeToken(user.ID, domain.TokenPurposeMFAChallenge, mfaChallengeTTL)
+	if err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al generar desafío MFA", err)
+	}
+
+	if err := uc.oneTimeTokenRepo.Create(ctx, token); err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al guardar desafío MFA", err)
 	}
 
 	return &LoginResponse{
-		Token: token,
+		MFARequired: true,
+		MFAToken:    rawToken,
 	}, nil
 }
 
@@ -3,6 +3,7 @@ package usecase_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"user-service/internal/domain"
 	"user-service/internal/usecase"
@@ -11,6 +12,25 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// newLoginUseCase crea el caso de uso sin retraso entre intentos, para que los
+// tests que no prueban el bloqueo no dependan del reloj.
+func newLoginUseCase(userRepo *mockUserRepository, jwtService *mockJWTService, verificationPolicy usecase.EmailVerificationPolicy) *usecase.LoginUseCase {
+	return usecase.NewLoginUseCase(
+		userRepo,
+		newMockRefreshTokenRepository(),
+		newMockSessionRepository(),
+		newMockOneTimeTokenRepository(),
+		newMockLoginAttemptRepository(),
+		&mockEventPublisher{},
+		jwtService,
+		&mockPasswordHasher{},
+		verificationPolicy,
+		domain.LockoutPolicy{},
+		domain.LockoutPolicy{},
+		domain.PasswordRotationPolicy{},
+	)
+}
+
 func TestLoginUseCase_Execute_Success(t *testing.T) {
 	// Arrange
 	userID := uuid.New()
@@ -30,7 +50,7 @@ func TestLoginUseCase_Execute_Success(t *testing.T) {
 	}
 	jwtService := &mockJWTService{}
 
-	useCase := usecase.NewLoginUseCase(userRepo, jwtService)
+	useCase := newLoginUseCase(userRepo, jwtService, usecase.EmailVerificationPolicy{})
 
 	req := usecase.LoginRequest{
 		Email:    "test@example.com",
@@ -52,6 +72,10 @@ func TestLoginUseCase_Execute_Success(t *testing.T) {
 	if response.Token == "" {
 		t.Error("Expected token, got empty string")
 	}
+
+	if response.RefreshToken == "" {
+		t.Error("Expected refresh token, got empty string")
+	}
 }
 
 func TestLoginUseCase_Execute_InvalidEmail(t *testing.T) {
@@ -59,7 +83,7 @@ func TestLoginUseCase_Execute_InvalidEmail(t *testing.T) {
 	userRepo := &mockUserRepository{users: make(map[string]*domain.User)}
 	jwtService := &mockJWTService{}
 
-	useCase := usecase.NewLoginUseCase(userRepo, jwtService)
+	useCase := newLoginUseCase(userRepo, jwtService, usecase.EmailVerificationPolicy{})
 
 	req := usecase.LoginRequest{
 		Email:    "nonexistent@example.com",
@@ -105,7 +129,7 @@ func TestLoginUseCase_Execute_InvalidPassword(t *testing.T) {
 	}
 	jwtService := &mockJWTService{}
 
-	useCase := usecase.NewLoginUseCase(userRepo, jwtService)
+	useCase := newLoginUseCase(userRepo, jwtService, usecase.EmailVerificationPolicy{})
 
 	req := usecase.LoginRequest{
 		Email:    "test@example.com",
@@ -132,3 +156,420 @@ func TestLoginUseCase_Execute_InvalidPassword(t *testing.T) {
 	}
 }
 
+func TestLoginUseCase_Execute_IssuesRolePermissions(t *testing.T) {
+	// Arrange
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+
+	user := &domain.User{
+		ID:       uuid.New(),
+		Email:    "admin@example.com",
+		Password: string(hashedPassword),
+		Name:     "Admin",
+		Role:     domain.RoleSupport,
+	}
+
+	userRepo := &mockUserRepository{
+		users: map[string]*domain.User{
+			"admin@example.com": user,
+		},
+	}
+	jwtService := &mockJWTService{}
+
+	useCase := newLoginUseCase(userRepo, jwtService, usecase.EmailVerificationPolicy{})
+
+	// Act
+	_, err := useCase.Execute(context.Background(), usecase.LoginRequest{
+		Email:    "admin@example.com",
+		Password: "password123",
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(jwtService.issued) != 1 {
+		t.Fatalf("Expected one access token, got %d", len(jwtService.issued))
+	}
+
+	principal := jwtService.issued[0]
+	if !principal.HasRole(domain.RoleSupport) || !principal.HasScope(domain.PermissionUsersRead) {
+		t.Errorf("Expected support role and users:read scope, got %+v", principal)
+	}
+
+	if principal.SessionID == "" {
+		t.Error("Expected session ID in access token")
+	}
+}
+
+func TestLoginUseCase_Execute_SuspendedUser(t *testing.T) {
+	// Arrange
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+
+	user := &domain.User{
+		ID:       uuid.New(),
+		Email:    "test@example.com",
+		Password: string(hashedPassword),
+		Name:     "Gustavo Hernández",
+		Status:   domain.UserStatusSuspended,
+	}
+
+	userRepo := &mockUserRepository{
+		users: map[string]*domain.User{
+			"test@example.com": user,
+		},
+	}
+
+	useCase := newLoginUseCase(userRepo, &mockJWTService{}, usecase.EmailVerificationPolicy{})
+
+	// Act
+	response, err := useCase.Execute(context.Background(), usecase.LoginRequest{
+		Email:    "test@example.com",
+		Password: "password123",
+	})
+
+	// Assert
+	if response != nil {
+		t.Error("Expected nil response for suspended user")
+	}
+
+	if errWithCode, ok := err.(*errors.ErrorWithCode); !ok || errWithCode.Code != 403 {
+		t.Errorf("Expected status code 403, got %v", err)
+	}
+}
+
+func TestLoginUseCase_Execute_UnverifiedEmailRequired(t *testing.T) {
+	// Arrange
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	user := &domain.User{
+		ID:       uuid.New(),
+		Email:    "test@example.com",
+		Password: string(hashedPassword),
+		Name:     "Gustavo Hernández",
+		Status:   domain.UserStatusActive,
+	}
+
+	userRepo := &mockUserRepository{
+		users: map[string]*domain.User{
+			"test@example.com": user,
+		},
+	}
+
+	useCase := newLoginUseCase(userRepo, &mockJWTService{}, usecase.EmailVerificationPolicy{Required: true})
+
+	// Act
+	response, err := useCase.Execute(context.Background(), usecase.LoginRequest{
+		Email:    "test@example.com",
+		Password: "password123",
+	})
+
+	// Assert
+	if response != nil {
+		t.Error("Expected nil response for unverified user")
+	}
+
+	if errWithCode, ok := err.(*errors.ErrorWithCode); !ok || errWithCode.Code != 403 {
+		t.Errorf("Expected status code 403, got %v", err)
+	}
+}
+
+func newLockoutUser(t *testing.T) *mockUserRepository {
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	user := &domain.User{
+		ID:       uuid.New(),
+		Email:    "test@example.com",
+		Password: string(hashedPassword),
+		Name:     "Gustavo Hernández",
+		Status:   domain.UserStatusActive,
+	}
+	return &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+}
+
+func assertLoginCode(t *testing.T, err error, code int) {
+	t.Helper()
+	errWithCode, ok := err.(*errors.ErrorWithCode)
+	if !ok || errWithCode.Code != code {
+		t.Fatalf("Expected status code %d, got %v", code, err)
+	}
+}
+
+func TestLoginUseCase_Execute_LockoutAfterMaxFailures(t *testing.T) {
+	// Arrange
+	userRepo := newLockoutUser(t)
+	attemptRepo := newMockLoginAttemptRepository()
+	policy := domain.LockoutPolicy{MaxFailures: 3, LockoutDuration: time.Minute}
+
+	useCase := usecase.NewLoginUseCase(userRepo, newMockRefreshTokenRepository(), newMockSessionRepository(), newMockOneTimeTokenRepository(), attemptRepo, &mockEventPublisher{}, &mockJWTService{}, &mockPasswordHasher{}, usecase.EmailVerificationPolicy{}, policy, domain.LockoutPolicy{}, domain.PasswordRotationPolicy{})
+
+	// Act & Assert
+	for _, email := range []string{"test@example.com", "nobody@example.com"} {
+		for i := 0; i < policy.MaxFailures; i++ {
+			_, err := useCase.Execute(context.Background(), usecase.LoginRequest{Email: email, Password: "wrongpassword"})
+			assertLoginCode(t, err, 401)
+		}
+
+		// Bloqueada incluso con la contraseña correcta, y con la misma respuesta
+		// exista o no el email
+		_, err := useCase.Execute(context.Background(), usecase.LoginRequest{Email: email, Password: "password123"})
+		assertLoginCode(t, err, 429)
+	}
+}
+
+func TestLoginUseCase_Execute_LockExpires(t *testing.T) {
+	// Arrange
+	userRepo := newLockoutUser(t)
+	attemptRepo := newMockLoginAttemptRepository()
+	expired := time.Now().Add(-time.Second)
+	key := domain.AccountAttemptKey("test@example.com")
+	attemptRepo.attempts[key] = &domain.LoginAttempt{Key: key, Failures: 3, LastFailureAt: expired, LockedUntil: &expired}
+	policy := domain.LockoutPolicy{MaxFailures: 3, LockoutDuration: time.Minute}
+
+	useCase := usecase.NewLoginUseCase(userRepo, newMockRefreshTokenRepository(), newMockSessionRepository(), newMockOneTimeTokenRepository(), attemptRepo, &mockEventPublisher{}, &mockJWTService{}, &mockPasswordHasher{}, usecase.EmailVerificationPolicy{}, policy, domain.LockoutPolicy{}, domain.PasswordRotationPolicy{})
+
+	// Act
+	response, err := useCase.Execute(context.Background(), usecase.LoginRequest{Email: "test@example.com", Password: "password123"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error after lock expiry, got %v", err)
+	}
+
+	if response.Token == "" {
+		t.Error("Expected token after lock expiry")
+	}
+
+	if _, exists := attemptRepo.attempts[key]; exists {
+		t.Error("Expected counter to be reset")
+	}
+}
+
+func TestLoginUseCase_Execute_ProgressiveDelay(t *testing.T) {
+	// Arrange
+	userRepo := newLockoutUser(t)
+	policy := domain.LockoutPolicy{MaxFailures: 5, LockoutDuration: time.Hour, BaseDelay: time.Minute, MaxDelay: time.Hour}
+
+	useCase := usecase.NewLoginUseCase(userRepo, newMockRefreshTokenRepository(), newMockSessionRepository(), newMockOneTimeTokenRepository(), newMockLoginAttemptRepository(), &mockEventPublisher{}, &mockJWTService{}, &mockPasswordHasher{}, usecase.EmailVerificationPolicy{}, policy, domain.LockoutPolicy{}, domain.PasswordRotationPolicy{})
+
+	// Act
+	_, first := useCase.Execute(context.Background(), usecase.LoginRequest{Email: "test@example.com", Password: "wrongpassword"})
+	_, second := useCase.Execute(context.Background(), usecase.LoginRequest{Email: "test@example.com", Password: "password123"})
+
+	// Assert
+	assertLoginCode(t, first, 401)
+	assertLoginCode(t, second, 429)
+}
+
+func TestLoginUseCase_Execute_IPLockout(t *testing.T) {
+	// Arrange
+	userRepo := newLockoutUser(t)
+	ipPolicy := domain.LockoutPolicy{MaxFailures: 2, LockoutDuration: time.Minute}
+
+	useCase := usecase.NewLoginUseCase(userRepo, newMockRefreshTokenRepository(), newMockSessionRepository(), newMockOneTimeTokenRepository(), newMockLoginAttemptRepository(), &mockEventPublisher{}, &mockJWTService{}, &mockPasswordHasher{}, usecase.EmailVerificationPolicy{}, domain.LockoutPolicy{}, ipPolicy, domain.PasswordRotationPolicy{})
+
+	// Act
+	for _, email := range []string{"a@example.com", "b@example.com"} {
+		_, err := useCase.Execute(context.Background(), usecase.LoginRequest{Email: email, Password: "wrongpassword", IPAddress: "10.0.0.1"})
+		assertLoginCode(t, err, 401)
+	}
+
+	_, blocked := useCase.Execute(context.Background(), usecase.LoginRequest{Email: "test@example.com", Password: "password123", IPAddress: "10.0.0.1"})
+	response, otherIP := useCase.Execute(context.Background(), usecase.LoginRequest{Email: "test@example.com", Password: "password123", IPAddress: "10.0.0.2"})
+
+	// Assert
+	assertLoginCode(t, blocked, 429)
+
+	if otherIP != nil || response == nil {
+		t.Fatalf("Expected login from another IP to succeed, got %v", otherIP)
+	}
+}
+
+func TestLoginUseCase_Execute_RejectsAttemptsBeyondLimit(t *testing.T) {
+	// Arrange: una ráfaga concurrente ya agotó los intentos, pero ninguno
+	// terminó todavía, así que la clave no está bloqueada
+	userRepo := newLockoutUser(t)
+	attemptRepo := newMockLoginAttemptRepository()
+	key := domain.AccountAttemptKey("test@example.com")
+	attemptRepo.attempts[key] = &domain.LoginAttempt{Key: key, Failures: 3, LastFailureAt: time.Now()}
+	policy := domain.LockoutPolicy{MaxFailures: 3, LockoutDuration: time.Minute}
+
+	useCase := usecase.NewLoginUseCase(userRepo, newMockRefreshTokenRepository(), newMockSessionRepository(), newMockOneTimeTokenRepository(), attemptRepo, &mockEventPublisher{}, &mockJWTService{}, &mockPasswordHasher{}, usecase.EmailVerificationPolicy{}, policy, domain.LockoutPolicy{}, domain.PasswordRotationPolicy{})
+
+	// Act
+	_, err := useCase.Execute(context.Background(), usecase.LoginRequest{Email: "test@example.com", Password: "password123"})
+
+	// Assert
+	assertLoginCode(t, err, 429)
+
+	if attemptRepo.attempts[key].LockedUntil == nil {
+		t.Error("Expected key to be locked")
+	}
+}
+
+func TestLoginUseCase_Execute_SuccessReleasesIPAttempt(t *testing.T) {
+	// Arrange
+	userRepo := newLockoutUser(t)
+	attemptRepo := newMockLoginAttemptRepository()
+	key := domain.IPAttemptKey("10.0.0.1")
+	attemptRepo.attempts[key] = &domain.LoginAttempt{Key: key, Failures: 1, LastFailureAt: time.Now()}
+	ipPolicy := domain.LockoutPolicy{MaxFailures: 3, LockoutDuration: time.Minute}
+
+	useCase := usecase.NewLoginUseCase(userRepo, newMockRefreshTokenRepository(), newMockSessionRepository(), newMockOneTimeTokenRepository(), attemptRepo, &mockEventPublisher{}, &mockJWTService{}, &mockPasswordHasher{}, usecase.EmailVerificationPolicy{}, domain.LockoutPolicy{}, ipPolicy, domain.PasswordRotationPolicy{})
+
+	// Act
+	_, err := useCase.Execute(context.Background(), usecase.LoginRequest{Email: "test@example.com", Password: "password123", IPAddress: "10.0.0.1"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if failures := attemptRepo.attempts[key].Failures; failures != 1 {
+		t.Errorf("Expected IP failures to stay at 1, got %d", failures)
+	}
+}
+
+func TestLoginUseCase_Execute_RehashesOutdatedPassword(t *testing.T) {
+	// Arrange
+	userRepo := newLockoutUser(t)
+	user := userRepo.users["test@example.com"]
+	hasher := &mockPasswordHasher{}
+
+	useCase := newLoginUseCase(userRepo, &mockJWTService{}, usecase.EmailVerificationPolicy{})
+
+	// Act
+	_, err := useCase.Execute(context.Background(), usecase.LoginRequest{Email: "test@example.com", Password: "password123"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if hasher.NeedsRehash(user.Password) {
+		t.Error("Expected password hash to be upgraded")
+	}
+
+	if !user.VerifyPassword(hasher, "password123") {
+		t.Error("Expected upgraded hash to verify the same password")
+	}
+}
+
+func TestLoginUseCase_Execute_NoRehashOnFailure(t *testing.T) {
+	// Arrange
+	userRepo := newLockoutUser(t)
+	user := userRepo.users["test@example.com"]
+	original := user.Password
+
+	useCase := newLoginUseCase(userRepo, &mockJWTService{}, usecase.EmailVerificationPolicy{})
+
+	// Act
+	_, err := useCase.Execute(context.Background(), usecase.LoginRequest{Email: "test@example.com", Password: "wrongpassword"})
+
+	// Assert
+	assertLoginCode(t, err, 401)
+
+	if user.Password != original {
+		t.Error("Expected password hash to stay unchanged")
+	}
+}
+
+
+func TestLoginUseCase_Execute_PasswordExpired(t *testing.T) {
+	// Arrange
+	userRepo := newLockoutUser(t)
+	user := userRepo.users["test@example.com"]
+	changedAt := time.Now().Add(-91 * 24 * time.Hour)
+	user.PasswordChangedAt = &changedAt
+	refreshRepo := newMockRefreshTokenRepository()
+	jwtService := &mockJWTService{}
+	rotation := domain.PasswordRotationPolicy{MaxAge: 90 * 24 * time.Hour}
+
+	useCase := usecase.NewLoginUseCase(userRepo, refreshRepo, newMockSessionRepository(), newMockOneTimeTokenRepository(), newMockLoginAttemptRepository(), &mockEventPublisher{}, jwtService, &mockPasswordHasher{}, usecase.EmailVerificationPolicy{}, domain.LockoutPolicy{}, domain.LockoutPolicy{}, rotation)
+
+	// Act
+	response, err := useCase.Execute(context.Background(), usecase.LoginRequest{Email: "test@example.com", Password: "password123"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !response.PasswordExpired || response.Token == "" {
+		t.Fatalf("Expected password expired response with token, got %+v", response)
+	}
+
+	if response.RefreshToken != "" || len(refreshRepo.tokens) != 0 {
+		t.Error("Expected no refresh token for expired password")
+	}
+
+	issued := jwtService.issued[len(jwtService.issued)-1]
+	if !issued.IsRestricted() || len(issued.Roles) != 0 {
+		t.Errorf("Expected restricted token without roles, got %+v", issued)
+	}
+}
+
+func TestLoginUseCase_Execute_PasswordNotExpired(t *testing.T) {
+	// Arrange
+	userRepo := newLockoutUser(t)
+	user := userRepo.users["test@example.com"]
+	changedAt := time.Now().Add(-89 * 24 * time.Hour)
+	user.PasswordChangedAt = &changedAt
+	rotation := domain.PasswordRotationPolicy{MaxAge: 90 * 24 * time.Hour}
+
+	useCase := usecase.NewLoginUseCase(userRepo, newMockRefreshTokenRepository(), newMockSessionRepository(), newMockOneTimeTokenRepository(), newMockLoginAttemptRepository(), &mockEventPublisher{}, &mockJWTService{}, &mockPasswordHasher{}, usecase.EmailVerificationPolicy{}, domain.LockoutPolicy{}, domain.LockoutPolicy{}, rotation)
+
+	// Act
+	response, err := useCase.Execute(context.Background(), usecase.LoginRequest{Email: "test@example.com", Password: "password123"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if response.PasswordExpired || response.RefreshToken == "" {
+		t.Errorf("Expected regular session, got %+v", response)
+	}
+}
+
+func TestLoginUseCase_Execute_CreatesSession(t *testing.T) {
+	// Arrange
+	userRepo := newLockoutUser(t)
+	user := userRepo.users["test@example.com"]
+	sessionRepo := newMockSessionRepository()
+	refreshRepo := newMockRefreshTokenRepository()
+	jwtService := &mockJWTService{}
+
+	useCase := usecase.NewLoginUseCase(userRepo, refreshRepo, sessionRepo, newMockOneTimeTokenRepository(), newMockLoginAttemptRepository(), &mockEventPublisher{}, jwtService, &mockPasswordHasher{}, usecase.EmailVerificationPolicy{}, domain.LockoutPolicy{}, domain.LockoutPolicy{}, domain.PasswordRotationPolicy{})
+
+	// Act
+	response, err := useCase.Execute(context.Background(), usecase.LoginRequest{
+		Email:     "test@example.com",
+		Password:  "password123",
+		IPAddress: "203.0.113.7",
+		UserAgent: "Mozilla/5.0",
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(sessionRepo.sessions) != 1 {
+		t.Fatalf("Expected 1 session, got %d", len(sessionRepo.sessions))
+	}
+
+	sessionID := jwtService.issued[len(jwtService.issued)-1].SessionID
+	session, exists := sessionRepo.sessions[sessionID]
+	if !exists {
+		t.Fatalf("Expected access token sid %q to match the session", sessionID)
+	}
+
+	if session.UserID != user.ID || session.IPAddress != "203.0.113.7" || session.UserAgent != "Mozilla/5.0" {
+		t.Errorf("Unexpected session %+v", session)
+	}
+
+	stored, _ := refreshRepo.FindByHash(context.Background(), domain.HashToken(response.RefreshToken))
+	if stored == nil || stored.FamilyID != session.ID {
+		t.Error("Expected refresh token family to be the session")
+	}
+}
+
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+)
+
+type LogoutUseCase struct {
+	refreshTokenRepo domain.RefreshTokenRepository
+	revocationStore  domain.TokenRevocationStore
+}
+
+func NewLogoutUseCase(
+	refreshTokenRepo domain.RefreshTokenRepository,
+	revocationStore domain.TokenRevocationStore,
+) *LogoutUseCase {
+	return &LogoutUseCase{
+		refreshTokenRepo: refreshTokenRepo,
+		revocationStore:  revocationStore,
+	}
+}
+
+type LogoutRequest struct {
+	Principal    *domain.Principal `json:"-"`
+	RefreshToken string            `json:"refresh_token"`
+}
+
+// Execute revoca el access token de la petición y cierra su sesión. Si se
+// envía un refresh token del usuario, revoca también su familia.
+func (uc *LogoutUseCase) Execute(ctx context.Context, req LogoutRequest) error {
+	if err := uc.revocationStore.RevokeToken(ctx, req.Principal); err != nil {
+		return errors.NewErrorWithCode(500, "Error al cerrar sesión", err)
+	}
+
+	if sessionID := req.Principal.SessionID; sessionID != "" {
+		if err := uc.refreshTokenRepo.RevokeFamily(ctx, sessionID); err != nil {
+			return errors.NewErrorWithCode(500, "Error al cerrar sesión", err)
+		}
+		if err := uc.revocationStore.RevokeSession(ctx, sessionID); err != nil {
+			return errors.NewErrorWithCode(500, "Error al cerrar sesión", err)
+		}
+	}
+
+	if req.RefreshToken == "" {
+		return nil
+	}
+
+	stored, err := uc.refreshTokenRepo.FindByHash(ctx, domain.HashToken(req.RefreshToken))
+	if err != nil || stored.UserID.String() != req.Principal.UserID {
+		return nil
+	}
+
+	if err := uc.refreshTokenRepo.RevokeFamily(ctx, stored.FamilyID.String()); err != nil {
+		return errors.NewErrorWithCode(500, "Error al cerrar sesión", err)
+	}
+
+	return nil
+}
+
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+
+	"user-service/internal/domain"
+)
+
+type LogoutAllUseCase struct {
+	refreshTokenRepo domain.RefreshTokenRepository
+	revocationStore  domain.TokenRevocationStore
+}
+
+func NewLogoutAllUseCase(
+	refreshTokenRepo domain.RefreshTokenRepository,
+	revocationStore domain.TokenRevocationStore,
+) *LogoutAllUseCase {
+	return &LogoutAllUseCase{
+		refreshTokenRepo: refreshTokenRepo,
+		revocationStore:  revocationStore,
+	}
+}
+
+// Execute invalida todos los access tokens emitidos hasta ahora para el usuario
+// y todos sus refresh tokens.
+func (uc *LogoutAllUseCase) Execute(ctx context.Context, userID string) error {
+	return revokeUserSessions(ctx, uc.refreshTokenRepo, uc.revocationStore, userID)
+}
+
@@ -0,0 +1,142 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/usecase"
+	"github.com/google/uuid"
+)
+
+func TestLogoutUseCase_Execute_RevokesTokenAndRefreshFamily(t *testing.T) {
+	// Arrange
+	refreshRepo := newMockRefreshTokenRepository()
+	revocationStore := newMockRevocationStore()
+	stored := seedRefreshToken(refreshRepo, "refresh-1", time.Now().Add(time.Hour))
+
+	principal := &domain.Principal{
+		UserID:    stored.UserID.String(),
+		TokenID:   uuid.NewString(),
+		IssuedAt:  time.Now(),
+		ExpiresAt: time.Now().Add(15 * time.Minute),
+	}
+
+	useCase := usecase.NewLogoutUseCase(refreshRepo, revocationStore)
+
+	// Act
+	err := useCase.Execute(context.Background(), usecase.LogoutRequest{
+		Principal:    principal,
+		RefreshToken: "refresh-1",
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if revoked, _ := revocationStore.IsRevoked(context.Background(), principal); !revoked {
+		t.Error("Expected access token to be revoked")
+	}
+
+	if stored.RevokedAt == nil {
+		t.Error("Expected refresh token family to be revoked")
+	}
+}
+
+func TestLogoutUseCase_Execute_IgnoresRefreshTokenOfAnotherUser(t *testing.T) {
+	// Arrange
+	refreshRepo := newMockRefreshTokenRepository()
+	stored := seedRefreshToken(refreshRepo, "refresh-1", time.Now().Add(time.Hour))
+
+	principal := &domain.Principal{
+		UserID:    uuid.NewString(),
+		TokenID:   uuid.NewString(),
+		IssuedAt:  time.Now(),
+		ExpiresAt: time.Now().Add(15 * time.Minute),
+	}
+
+	useCase := usecase.NewLogoutUseCase(refreshRepo, newMockRevocationStore())
+
+	// Act
+	err := useCase.Execute(context.Background(), usecase.LogoutRequest{
+		Principal:    principal,
+		RefreshToken: "refresh-1",
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if stored.RevokedAt != nil {
+		t.Error("Expected refresh token of another user to remain active")
+	}
+}
+
+func TestLogoutAllUseCase_Execute(t *testing.T) {
+	// Arrange
+	refreshRepo := newMockRefreshTokenRepository()
+	revocationStore := newMockRevocationStore()
+	stored := seedRefreshToken(refreshRepo, "refresh-1", time.Now().Add(time.Hour))
+
+	principal := &domain.Principal{
+		UserID:    stored.UserID.String(),
+		TokenID:   uuid.NewString(),
+		IssuedAt:  time.Now().Add(-time.Minute),
+		ExpiresAt: time.Now().Add(14 * time.Minute),
+	}
+
+	useCase := usecase.NewLogoutAllUseCase(refreshRepo, revocationStore)
+
+	// Act
+	err := useCase.Execute(context.Background(), stored.UserID.String())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if revoked, _ := revocationStore.IsRevoked(context.Background(), principal); !revoked {
+		t.Error("Expected previously issued access tokens to be revoked")
+	}
+
+	if stored.RevokedAt == nil {
+		t.Error("Expected refresh tokens of the user to be revoked")
+	}
+}
+
+func TestLogoutUseCase_Execute_RevokesSession(t *testing.T) {
+	// Arrange
+	refreshRepo := newMockRefreshTokenRepository()
+	revocationStore := newMockRevocationStore()
+	stored := seedRefreshToken(refreshRepo, "refresh-1", time.Now().Add(time.Hour))
+
+	principal := &domain.Principal{
+		UserID:    stored.UserID.String(),
+		SessionID: stored.FamilyID.String(),
+		TokenID:   uuid.NewString(),
+		IssuedAt:  time.Now(),
+		ExpiresAt: time.Now().Add(15 * time.Minute),
+	}
+
+	useCase := usecase.NewLogoutUseCase(refreshRepo, revocationStore)
+
+	// Act
+	err := useCase.Execute(context.Background(), usecase.LogoutRequest{Principal: principal})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if stored.RevokedAt == nil {
+		t.Error("Expected session refresh tokens to be revoked")
+	}
+
+	if !revocationStore.revokedSessions[principal.SessionID] {
+		t.Error("Expected session to be revoked")
+	}
+}
+
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"strings"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/pkg/totp"
+)
+
+const (
+	mfaChallengeTTL   = 5 * time.Minute
+	recoveryCodeCount = 10
+	// totpSkew acepta el código del paso anterior y del siguiente.
+	totpSkew = 1
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// generateRecoveryCodes devuelve los códigos en claro, con formato xxxxx-xxxxx,
+// y las entidades con su hash.
+func generateRecoveryCodes(user *domain.User) ([]string, []*domain.RecoveryCode, error) {
+	raw := make([]string, 0, recoveryCodeCount)
+	codes := make([]*domain.RecoveryCode, 0, recoveryCodeCount)
+
+	for i := 0; i < recoveryCodeCount; i++ {
+		buf := make([]byte, 7)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, fmt.Errorf("error al generar código de recuperación: %w", err)
+		}
+		code := strings.ToLower(recoveryCodeEncoding.EncodeToString(buf)[:10])
+
+		raw = append(raw, code[:5]+"-"+code[5:])
+		codes = append(codes, &domain.RecoveryCode{
+			UserID:   user.ID,
+			CodeHash: domain.HashToken(code),
+		})
+	}
+
+	return raw, codes, nil
+}
+
+// normalizeRecoveryCode acepta el código con o sin guion y en cualquier caja.
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.ReplaceAll(code, "-", "")
+}
+
+// verifyTOTPCode valida el código contra el secreto del usuario y rechaza los
+// pasos ya usados. Si es válido actualiza TOTPLastStep; el llamador debe
+// persistir al usuario.
+func verifyTOTPCode(cipher domain.SecretCipher, user *domain.User, code string, now time.Time) (bool, error) {
+	if user.TOTPSecret == "" {
+		return false, nil
+	}
+
+	secret, err := cipher.Decrypt(user.TOTPSecret)
+	if err != nil {
+		return false, err
+	}
+
+	step, ok := totp.Validate(secret, code, now, totpSkew)
+	if !ok || step <= user.TOTPLastStep {
+		return false, nil
+	}
+
+	user.TOTPLastStep = step
+	return true, nil
+}
+
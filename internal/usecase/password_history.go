@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+)
+
+// PasswordHistory impide reutilizar las últimas contraseñas del usuario. Con
+// tamaño 0 no comprueba ni registra nada.
+type PasswordHistory struct {
+	repo   domain.PasswordHistoryRepository
+	hasher domain.PasswordHasher
+	size   int
+}
+
+func NewPasswordHistory(repo domain.PasswordHistoryRepository, hasher domain.PasswordHasher, size int) *PasswordHistory {
+	return &PasswordHistory{
+		repo:   repo,
+		hasher: hasher,
+		size:   size,
+	}
+}
+
+// CheckReuse compara password con la contraseña actual y con las anteriores
+// guardadas. La actual se compara aparte porque los usuarios previos al
+// historial no tienen entradas.
+func (h *PasswordHistory) CheckReuse(ctx context.Context, user *domain.User, password string) error {
+	if h.size <= 0 {
+		return nil
+	}
+
+	entries, err := h.repo.ListRecent(ctx, user.ID.String(), h.size)
+	if err != nil {
+		return errors.NewErrorWithCode(500, "Error al verificar historial de contraseñas", err)
+	}
+
+	hashes := []string{user.Password}
+	for _, entry := range entries {
+		if entry.PasswordHash != user.Password {
+			hashes = append(hashes, entry.PasswordHash)
+		}
+	}
+
+	for _, hash := range hashes {
+		if ok, _ := h.hasher.Verify(password, hash); ok {
+			violations := []domain.PasswordViolation{{
+				Rule:    domain.PasswordRuleReused,
+				Message: fmt.Sprintf("la contraseña no puede ser ninguna de las últimas %d usadas", h.size),
+			}}
+			return errors.NewErrorWithDetails(400, "Contraseña inválida", &domain.PasswordPolicyError{Violations: violations}, violations)
+		}
+	}
+
+	return nil
+}
+
+// Record guarda el hash actual del usuario y descarta las entradas que
+// exceden el tamaño del historial.
+func (h *PasswordHistory) Record(ctx context.Context, user *domain.User) error {
+	if h.size <= 0 {
+		return nil
+	}
+
+	entry := &domain.PasswordHistoryEntry{
+		UserID:       user.ID,
+		PasswordHash: user.Password,
+		CreatedAt:    time.Now(),
+	}
+	if err := h.repo.Add(ctx, entry); err != nil {
+		return errors.NewErrorWithCode(500, "Error al guardar historial de contraseñas", err)
+	}
+
+	if err := h.repo.Prune(ctx, user.ID.String(), h.size); err != nil {
+		return errors.NewErrorWithCode(500, "Error al guardar historial de contraseñas", err)
+	}
+
+	return nil
+}
+
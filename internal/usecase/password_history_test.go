@@ -0,0 +1,101 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"user-service/internal/domain"
+	"user-service/internal/usecase"
+)
+
+// setPassword simula un cambio de contraseña completo: hash y registro.
+func setPassword(t *testing.T, history *usecase.PasswordHistory, user *domain.User, password string) {
+	t.Helper()
+	if err := user.HashPassword(&mockPasswordHasher{}, password); err != nil {
+		t.Fatalf("Expected no error hashing password, got %v", err)
+	}
+	if err := history.Record(context.Background(), user); err != nil {
+		t.Fatalf("Expected no error recording password, got %v", err)
+	}
+}
+
+func TestPasswordHistory_CheckReuse(t *testing.T) {
+	// Arrange
+	repo := newMockPasswordHistoryRepository()
+	history := usecase.NewPasswordHistory(repo, &mockPasswordHasher{}, 3)
+	user := newPasswordUser(t, "password-1")
+
+	for _, password := range []string{"password-1", "password-2", "password-3", "password-4"} {
+		setPassword(t, history, user, password)
+	}
+
+	tests := []struct {
+		password string
+		reused   bool
+	}{
+		{password: "password-4", reused: true},
+		{password: "password-3", reused: true},
+		{password: "password-2", reused: true},
+		{password: "password-1", reused: false},
+		{password: "password-5", reused: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.password, func(t *testing.T) {
+			// Act
+			err := history.CheckReuse(context.Background(), user, tt.password)
+
+			// Assert
+			if !tt.reused {
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				return
+			}
+			rules := violatedRules(t, err)
+			if len(rules) != 1 || rules[0] != domain.PasswordRuleReused {
+				t.Errorf("Expected only %s, got %v", domain.PasswordRuleReused, rules)
+			}
+		})
+	}
+
+	if len(repo.entries) != 3 {
+		t.Errorf("Expected history pruned to 3 entries, got %d", len(repo.entries))
+	}
+}
+
+func TestPasswordHistory_CheckReuse_LegacyUserWithoutHistory(t *testing.T) {
+	// Arrange
+	history := usecase.NewPasswordHistory(newMockPasswordHistoryRepository(), &mockPasswordHasher{}, 3)
+	user := newPasswordUser(t, "password123")
+
+	// Act
+	err := history.CheckReuse(context.Background(), user, "password123")
+
+	// Assert
+	rules := violatedRules(t, err)
+	if len(rules) != 1 || rules[0] != domain.PasswordRuleReused {
+		t.Errorf("Expected only %s, got %v", domain.PasswordRuleReused, rules)
+	}
+}
+
+func TestPasswordHistory_Disabled(t *testing.T) {
+	// Arrange
+	repo := newMockPasswordHistoryRepository()
+	history := usecase.NewPasswordHistory(repo, &mockPasswordHasher{}, 0)
+	user := newPasswordUser(t, "password123")
+
+	// Act
+	recordErr := history.Record(context.Background(), user)
+	checkErr := history.CheckReuse(context.Background(), user, "password123")
+
+	// Assert
+	if recordErr != nil || checkErr != nil {
+		t.Fatalf("Expected no errors, got %v, %v", recordErr, checkErr)
+	}
+
+	if len(repo.entries) != 0 {
+		t.Errorf("Expected no history entries, got %d", len(repo.entries))
+	}
+}
+
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+)
+
+// PasswordValidator aplica la política de contraseñas y, si hay un corpus de
+// contraseñas filtradas configurado, rechaza las que aparezcan en él.
+type PasswordValidator struct {
+	policy          domain.PasswordPolicy
+	breachedChecker domain.BreachedPasswordChecker
+}
+
+// NewPasswordValidator acepta breachedChecker nil para omitir esa comprobación.
+func NewPasswordValidator(policy domain.PasswordPolicy, breachedChecker domain.BreachedPasswordChecker) *PasswordValidator {
+	return &PasswordValidator{
+		policy:          policy,
+		breachedChecker: breachedChecker,
+	}
+}
+
+// Validate devuelve un error 400 con todas las reglas incumplidas en Details.
+func (v *PasswordValidator) Validate(ctx context.Context, password, email, name string) error {
+	violations := v.policy.Check(password, email, name)
+
+	if v.breachedChecker != nil {
+		breached, err := v.breachedChecker.IsBreached(ctx, password)
+		if err != nil {
+			return errors.NewErrorWithCode(500, "Error al verificar contraseña", err)
+		}
+		if breached {
+			violations = append(violations, domain.PasswordViolation{
+				Rule:    domain.PasswordRuleBreached,
+				Message: "la contraseña aparece en filtraciones conocidas",
+			})
+		}
+	}
+
+	if len(violations) > 0 {
+		return errors.NewErrorWithDetails(400, "Contraseña inválida", &domain.PasswordPolicyError{Violations: violations}, violations)
+	}
+
+	return nil
+}
+
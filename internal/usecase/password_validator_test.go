@@ -0,0 +1,137 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"user-service/internal/domain"
+	"user-service/internal/usecase"
+	"user-service/pkg/errors"
+)
+
+func violatedRules(t *testing.T, err error) []string {
+	t.Helper()
+	errWithCode, ok := err.(*errors.ErrorWithCode)
+	if !ok || errWithCode.Code != 400 {
+		t.Fatalf("Expected status code 400, got %v", err)
+	}
+	violations, ok := errWithCode.Details.([]domain.PasswordViolation)
+	if !ok {
+		t.Fatalf("Expected password violations in details, got %T", errWithCode.Details)
+	}
+	rules := make([]string, len(violations))
+	for i, violation := range violations {
+		rules[i] = violation.Rule
+	}
+	return rules
+}
+
+func TestPasswordValidator_Validate_DefaultPolicy(t *testing.T) {
+	// Arrange
+	validator := newPasswordValidator()
+
+	// Act
+	err := validator.Validate(context.Background(), "password123", "test@example.com", "Test User")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestPasswordValidator_Validate_ReportsEveryRule(t *testing.T) {
+	// Arrange
+	policy := domain.PasswordPolicy{
+		MinLength:        12,
+		MaxLength:        72,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+	}
+	validator := usecase.NewPasswordValidator(policy, nil)
+
+	// Act
+	err := validator.Validate(context.Background(), "abc", "", "")
+
+	// Assert
+	rules := violatedRules(t, err)
+	expected := []string{
+		domain.PasswordRuleMinLength,
+		domain.PasswordRuleUppercase,
+		domain.PasswordRuleDigit,
+		domain.PasswordRuleSymbol,
+	}
+	if len(rules) != len(expected) {
+		t.Fatalf("Expected rules %v, got %v", expected, rules)
+	}
+	for i := range expected {
+		if rules[i] != expected[i] {
+			t.Errorf("Expected rule %s at %d, got %s", expected[i], i, rules[i])
+		}
+	}
+}
+
+func TestPasswordValidator_Validate_MaxLength(t *testing.T) {
+	// Arrange
+	validator := newPasswordValidator()
+	long := make([]byte, domain.MaxPasswordLength+1)
+	for i := range long {
+		long[i] = 'a'
+	}
+
+	// Act
+	err := validator.Validate(context.Background(), string(long), "", "")
+
+	// Assert
+	rules := violatedRules(t, err)
+	if len(rules) != 1 || rules[0] != domain.PasswordRuleMaxLength {
+		t.Errorf("Expected only %s, got %v", domain.PasswordRuleMaxLength, rules)
+	}
+}
+
+func TestPasswordValidator_Validate_PersonalInfo(t *testing.T) {
+	// Arrange
+	validator := newPasswordValidator()
+
+	tests := []struct {
+		name     string
+		password string
+	}{
+		{name: "email local part", password: "Gustavo.h2024"},
+		{name: "name word", password: "hernandez2024!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := validator.Validate(context.Background(), tt.password, "gustavo.h@example.com", "Gustavo Hernandez")
+
+			// Assert
+			rules := violatedRules(t, err)
+			if len(rules) != 1 || rules[0] != domain.PasswordRulePersonalInfo {
+				t.Errorf("Expected only %s, got %v", domain.PasswordRulePersonalInfo, rules)
+			}
+		})
+	}
+}
+
+func TestPasswordValidator_Validate_Breached(t *testing.T) {
+	// Arrange
+	checker := &mockBreachedPasswordChecker{breached: map[string]bool{"password123": true}}
+	validator := usecase.NewPasswordValidator(domain.DefaultPasswordPolicy(), checker)
+
+	// Act
+	err := validator.Validate(context.Background(), "password123", "test@example.com", "Test User")
+
+	// Assert
+	rules := violatedRules(t, err)
+	if len(rules) != 1 || rules[0] != domain.PasswordRuleBreached {
+		t.Errorf("Expected only %s, got %v", domain.PasswordRuleBreached, rules)
+	}
+
+	if err := validator.Validate(context.Background(), "unbreached-passphrase", "test@example.com", "Test User"); err != nil {
+		t.Errorf("Expected no error for unbreached password, got %v", err)
+	}
+}
+
@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"user-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// RecordUserCreatedUseCase guarda en user_events los user.created que recibe
+// el consumidor. No atiende peticiones HTTP: sus errores deciden si el mensaje
+// se reintenta (fallos del repositorio) o se aparca (domain.ErrInvalidEvent).
+type RecordUserCreatedUseCase struct {
+	userEventRepo domain.UserEventRepository
+}
+
+func NewRecordUserCreatedUseCase(userEventRepo domain.UserEventRepository) *RecordUserCreatedUseCase {
+	return &RecordUserCreatedUseCase{userEventRepo: userEventRepo}
+}
+
+func (uc *RecordUserCreatedUseCase) Execute(ctx context.Context, payload *domain.UserCreatedPayload) error {
+	userID, err := uuid.Parse(payload.UserID)
+	if err != nil {
+		return fmt.Errorf("user_id %q: %w", payload.UserID, domain.ErrInvalidEvent)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("error al serializar payload: %w", domain.ErrInvalidEvent)
+	}
+
+	event := &domain.UserEvent{
+		UserID:    userID,
+		EventType: domain.EventUserCreated,
+		Payload:   body,
+	}
+
+	if err := uc.userEventRepo.Create(ctx, event); err != nil {
+		return fmt.Errorf("error al guardar evento en auditoría: %w", err)
+	}
+
+	return nil
+}
+
@@ -0,0 +1,85 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/usecase"
+	"github.com/google/uuid"
+)
+
+type mockUserEventRepository struct {
+	events []*domain.UserEvent
+	err    error
+}
+
+func (m *mockUserEventRepository) Create(ctx context.Context, event *domain.UserEvent) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.events = append(m.events, event)
+	return nil
+}
+
+func newUserCreatedPayload(userID string) *domain.UserCreatedPayload {
+	return &domain.UserCreatedPayload{UserID: userID, Email: "test@example.com", CreatedAt: time.Now()}
+}
+
+func TestRecordUserCreatedUseCase_Execute_Success(t *testing.T) {
+	// Arrange
+	repo := &mockUserEventRepository{}
+	useCase := usecase.NewRecordUserCreatedUseCase(repo)
+	userID := uuid.New()
+
+	// Act
+	err := useCase.Execute(context.Background(), newUserCreatedPayload(userID.String()))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(repo.events) != 1 || repo.events[0].UserID != userID || repo.events[0].EventType != domain.EventUserCreated {
+		t.Errorf("Unexpected events %+v", repo.events)
+	}
+}
+
+func TestRecordUserCreatedUseCase_Execute_RepositoryErrorIsRetryable(t *testing.T) {
+	// Arrange
+	repoErr := errors.New("conexión rechazada")
+	useCase := usecase.NewRecordUserCreatedUseCase(&mockUserEventRepository{err: repoErr})
+
+	// Act
+	err := useCase.Execute(context.Background(), newUserCreatedPayload(uuid.NewString()))
+
+	// Assert
+	if !errors.Is(err, repoErr) {
+		t.Fatalf("Expected repository error, got %v", err)
+	}
+
+	if errors.Is(err, domain.ErrInvalidEvent) {
+		t.Error("Expected a retryable error, got ErrInvalidEvent")
+	}
+}
+
+func TestRecordUserCreatedUseCase_Execute_InvalidUserID(t *testing.T) {
+	// Arrange
+	repo := &mockUserEventRepository{}
+	useCase := usecase.NewRecordUserCreatedUseCase(repo)
+
+	// Act
+	err := useCase.Execute(context.Background(), newUserCreatedPayload("not-a-uuid"))
+
+	// Assert
+	if !errors.Is(err, domain.ErrInvalidEvent) {
+		t.Fatalf("Expected ErrInvalidEvent, got %v", err)
+	}
+
+	if len(repo.events) != 0 {
+		t.Error("Expected no event to be stored")
+	}
+}
+
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+)
+
+type RefreshTokenUseCase struct {
+	userRepo         domain.UserRepository
+	refreshTokenRepo domain.RefreshTokenRepository
+	sessionRepo      domain.SessionRepository
+	revocationStore  domain.TokenRevocationStore
+	jwtService       domain.JWTService
+}
+
+func NewRefreshTokenUseCase(
+	userRepo domain.UserRepository,
+	refreshTokenRepo domain.RefreshTokenRepository,
+	sessionRepo domain.SessionRepository,
+	revocationStore domain.TokenRevocationStore,
+	jwtService domain.JWTService,
+) *RefreshTokenUseCase {
+	return &RefreshTokenUseCase{
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		sessionRepo:      sessionRepo,
+		revocationStore:  revocationStore,
+		jwtService:       jwtService,
+	}
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+	IPAddress    string `json:"-"`
+}
+
+type RefreshTokenResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (uc *RefreshTokenUseCase) Execute(ctx context.Context, req RefreshTokenRequest) (*RefreshTokenResponse, error) {
+	stored, err := uc.refreshTokenRepo.FindByHash(ctx, domain.HashToken(req.RefreshToken))
+	if err != nil {
+		return nil, errors.NewErrorWithCode(401, "Refresh token inválido", errors.ErrInvalidRefreshToken)
+	}
+
+	if stored.RevokedAt != nil {
+		return nil, errors.NewErrorWithCode(401, "Refresh token inválido", errors.ErrInvalidRefreshToken)
+	}
+
+	// Un token ya rotado que vuelve a presentarse indica que fue robado:
+	// se revoca toda la familia, y los access tokens de la sesión, para cortar
+	// tanto al atacante como al cliente legítimo.
+	if stored.UsedAt != nil {
+		return nil, uc.revokeFamily(ctx, stored)
+	}
+
+	if stored.IsExpired(time.Now()) {
+		return nil, errors.NewErrorWithCode(401, "Refresh token expirado", errors.ErrInvalidRefreshToken)
+	}
+
+	marked, err := uc.refreshTokenRepo.MarkUsed(ctx, stored.ID.String())
+	if err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al rotar refresh token", err)
+	}
+	if !marked {
+		return nil, uc.revokeFamily(ctx, stored)
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, stored.UserID.String())
+	if err != nil || user.IsSuspended() {
+		return nil, errors.NewErrorWithCode(401, "Refresh token inválido", errors.ErrInvalidRefreshToken)
+	}
+
+	tokens, err := issueTokenPair(ctx, uc.jwtService, uc.refreshTokenRepo, user, stored.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// La última actividad es informativa: un fallo al guardarla no invalida
+	// la rotación.
+	uc.sessionRepo.Touch(ctx, stored.FamilyID.String(), req.IPAddress, time.Now())
+
+	return &RefreshTokenResponse{
+		Token:        tokens.accessToken,
+		RefreshToken: tokens.refreshToken,
+	}, nil
+}
+
+func (uc *RefreshTokenUseCase) revokeFamily(ctx context.Context, stored *domain.RefreshToken) error {
+	if err := uc.refreshTokenRepo.RevokeFamily(ctx, stored.FamilyID.String()); err != nil {
+		return errors.NewErrorWithCode(500, "Error al revocar refresh tokens", err)
+	}
+	if err := uc.revocationStore.RevokeSession(ctx, stored.FamilyID.String()); err != nil {
+		return errors.NewErrorWithCode(500, "Error al revocar la sesión", err)
+	}
+	return errors.NewErrorWithCode(401, "Refresh token reutilizado", errors.ErrRefreshTokenReused)
+}
+
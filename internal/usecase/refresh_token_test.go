@@ -0,0 +1,198 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/usecase"
+	"user-service/pkg/errors"
+	"github.com/google/uuid"
+)
+
+func seedRefreshToken(repo *mockRefreshTokenRepository, raw string, expiresAt time.Time) *domain.RefreshToken {
+	return seedRefreshTokenForUser(repo, raw, uuid.New(), expiresAt)
+}
+
+func seedRefreshTokenForUser(repo *mockRefreshTokenRepository, raw string, userID uuid.UUID, expiresAt time.Time) *domain.RefreshToken {
+	token := &domain.RefreshToken{
+		UserID:    userID,
+		FamilyID:  uuid.New(),
+		TokenHash: domain.HashToken(raw),
+		ExpiresAt: expiresAt,
+	}
+	repo.Create(context.Background(), token)
+	return token
+}
+
+func TestRefreshTokenUseCase_Execute_Success(t *testing.T) {
+	// Arrange
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com", Role: domain.RoleUser}
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+	refreshRepo := newMockRefreshTokenRepository()
+	stored := seedRefreshTokenForUser(refreshRepo, "refresh-1", user.ID, time.Now().Add(time.Hour))
+
+	useCase := usecase.NewRefreshTokenUseCase(userRepo, refreshRepo, newMockSessionRepository(), newMockRevocationStore(), &mockJWTService{})
+
+	// Act
+	response, err := useCase.Execute(context.Background(), usecase.RefreshTokenRequest{RefreshToken: "refresh-1"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if response.RefreshToken == "" || response.RefreshToken == "refresh-1" {
+		t.Errorf("Expected a rotated refresh token, got %q", response.RefreshToken)
+	}
+
+	if stored.UsedAt == nil {
+		t.Error("Expected presented refresh token to be marked as used")
+	}
+
+	rotated, err := refreshRepo.FindByHash(context.Background(), domain.HashToken(response.RefreshToken))
+	if err != nil {
+		t.Fatalf("Expected rotated refresh token to be persisted, got %v", err)
+	}
+
+	if rotated.FamilyID != stored.FamilyID {
+		t.Error("Expected rotated refresh token to keep the same family")
+	}
+}
+
+func TestRefreshTokenUseCase_Execute_ReuseRevokesFamily(t *testing.T) {
+	// Arrange
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com", Role: domain.RoleUser}
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+	refreshRepo := newMockRefreshTokenRepository()
+	seedRefreshTokenForUser(refreshRepo, "refresh-1", user.ID, time.Now().Add(time.Hour))
+
+	useCase := usecase.NewRefreshTokenUseCase(userRepo, refreshRepo, newMockSessionRepository(), newMockRevocationStore(), &mockJWTService{})
+
+	first, err := useCase.Execute(context.Background(), usecase.RefreshTokenRequest{RefreshToken: "refresh-1"})
+	if err != nil {
+		t.Fatalf("Error rotating refresh token: %v", err)
+	}
+
+	// Act - se presenta otra vez el token ya rotado
+	_, err = useCase.Execute(context.Background(), usecase.RefreshTokenRequest{RefreshToken: "refresh-1"})
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected error for reused refresh token, got nil")
+	}
+
+	if errWithCode, ok := err.(*errors.ErrorWithCode); ok {
+		if errWithCode.Code != 401 {
+			t.Errorf("Expected status code 401, got %d", errWithCode.Code)
+		}
+	}
+
+	// El token legítimo emitido en la rotación también debe quedar revocado
+	if _, err := useCase.Execute(context.Background(), usecase.RefreshTokenRequest{RefreshToken: first.RefreshToken}); err == nil {
+		t.Error("Expected family to be revoked after reuse detection")
+	}
+}
+
+func TestRefreshTokenUseCase_Execute_ReuseRevokesSessionAccessTokens(t *testing.T) {
+	// Arrange
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com", Role: domain.RoleUser}
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+	refreshRepo := newMockRefreshTokenRepository()
+	stored := seedRefreshTokenForUser(refreshRepo, "refresh-1", user.ID, time.Now().Add(time.Hour))
+	revocationStore := newMockRevocationStore()
+
+	useCase := usecase.NewRefreshTokenUseCase(userRepo, refreshRepo, newMockSessionRepository(), revocationStore, &mockJWTService{})
+
+	if _, err := useCase.Execute(context.Background(), usecase.RefreshTokenRequest{RefreshToken: "refresh-1"}); err != nil {
+		t.Fatalf("Error rotating refresh token: %v", err)
+	}
+	// Access token emitido en la rotación, que el atacante pudo obtener
+	accessToken := &domain.Principal{
+		UserID:    user.ID.String(),
+		SessionID: stored.FamilyID.String(),
+		TokenID:   uuid.NewString(),
+		IssuedAt:  time.Now(),
+	}
+
+	// Act - se presenta otra vez el token ya rotado
+	_, err := useCase.Execute(context.Background(), usecase.RefreshTokenRequest{RefreshToken: "refresh-1"})
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected error for reused refresh token, got nil")
+	}
+
+	revoked, _ := revocationStore.IsRevoked(context.Background(), accessToken)
+	if !revoked {
+		t.Error("Expected access tokens of the reused family to be rejected")
+	}
+}
+
+func TestRefreshTokenUseCase_Execute_Expired(t *testing.T) {
+	// Arrange
+	refreshRepo := newMockRefreshTokenRepository()
+	seedRefreshToken(refreshRepo, "refresh-1", time.Now().Add(-time.Minute))
+
+	useCase := usecase.NewRefreshTokenUseCase(&mockUserRepository{users: make(map[string]*domain.User)}, refreshRepo, newMockSessionRepository(), newMockRevocationStore(), &mockJWTService{})
+
+	// Act
+	response, err := useCase.Execute(context.Background(), usecase.RefreshTokenRequest{RefreshToken: "refresh-1"})
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected error for expired refresh token, got nil")
+	}
+
+	if response != nil {
+		t.Error("Expected nil response for expired refresh token")
+	}
+}
+
+func TestRefreshTokenUseCase_Execute_Unknown(t *testing.T) {
+	// Arrange
+	useCase := usecase.NewRefreshTokenUseCase(&mockUserRepository{users: make(map[string]*domain.User)}, newMockRefreshTokenRepository(), newMockSessionRepository(), newMockRevocationStore(), &mockJWTService{})
+
+	// Act
+	_, err := useCase.Execute(context.Background(), usecase.RefreshTokenRequest{RefreshToken: "unknown"})
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected error for unknown refresh token, got nil")
+	}
+}
+
+func TestRefreshTokenUseCase_Execute_TouchesSession(t *testing.T) {
+	// Arrange
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com", Role: domain.RoleUser}
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+	refreshRepo := newMockRefreshTokenRepository()
+	sessionRepo := newMockSessionRepository()
+	session := seedSession(sessionRepo, user.ID)
+	session.LastSeenAt = time.Now().Add(-time.Hour)
+	stored := seedRefreshTokenForUser(refreshRepo, "refresh-1", user.ID, time.Now().Add(time.Hour))
+	stored.FamilyID = session.ID
+
+	useCase := usecase.NewRefreshTokenUseCase(userRepo, refreshRepo, sessionRepo, newMockRevocationStore(), &mockJWTService{})
+
+	// Act
+	_, err := useCase.Execute(context.Background(), usecase.RefreshTokenRequest{
+		RefreshToken: "refresh-1",
+		IPAddress:    "198.51.100.20",
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if time.Since(session.LastSeenAt) > time.Minute {
+		t.Error("Expected session last seen to be updated")
+	}
+
+	if session.IPAddress != "198.51.100.20" {
+		t.Errorf("Expected session IP to be updated, got %s", session.IPAddress)
+	}
+}
+
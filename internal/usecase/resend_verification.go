@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+)
+
+type ResendVerificationUseCase struct {
+	userRepo         domain.UserRepository
+	oneTimeTokenRepo domain.OneTimeTokenRepository
+	notifier         domain.Notifier
+	tokenTTL         time.Duration
+}
+
+func NewResendVerificationUseCase(
+	userRepo domain.UserRepository,
+	oneTimeTokenRepo domain.OneTimeTokenRepository,
+	notifier domain.Notifier,
+	policy EmailVerificationPolicy,
+) *ResendVerificationUseCase {
+	return &ResendVerificationUseCase{
+		userRepo:         userRepo,
+		oneTimeTokenRepo: oneTimeTokenRepo,
+		notifier:         notifier,
+		tokenTTL:         time.Duration(policy.TokenTTLMinutes) * time.Minute,
+	}
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email"`
+}
+
+// Execute reenvía el token sin revelar si el email existe o ya está verificado.
+// Los tokens anteriores se invalidan para que solo el último sea válido.
+func (uc *ResendVerificationUseCase) Execute(ctx context.Context, req ResendVerificationRequest) error {
+	user, err := uc.userRepo.FindByEmail(ctx, req.Email)
+	if err != nil || user.IsEmailVerified() || user.IsSuspended() {
+		return nil
+	}
+
+	if err := uc.oneTimeTokenRepo.InvalidateForUser(ctx, user.ID.String(), domain.TokenPurposeEmailVerification); err != nil {
+		return errors.NewErrorWithCode(500, "Error al invalidar tokens", err)
+	}
+
+	return sendEmailVerification(ctx, uc.oneTimeTokenRepo, uc.notifier, user, uc.tokenTTL)
+}
+
@@ -0,0 +1,68 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/usecase"
+	"github.com/google/uuid"
+)
+
+func TestResendVerificationUseCase_Execute_ReplacesPendingToken(t *testing.T) {
+	// Arrange
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com", Status: domain.UserStatusActive}
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+	tokenRepo := newMockOneTimeTokenRepository()
+	notifier := newMockNotifier()
+
+	_, previous, _ := domain.NewOneTimeToken(user.ID, domain.TokenPurposeEmailVerification, time.Hour)
+	tokenRepo.Create(context.Background(), previous)
+
+	useCase := usecase.NewResendVerificationUseCase(userRepo, tokenRepo, notifier, usecase.EmailVerificationPolicy{TokenTTLMinutes: 60})
+
+	// Act
+	err := useCase.Execute(context.Background(), usecase.ResendVerificationRequest{Email: user.Email})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if previous.UsedAt == nil {
+		t.Error("Expected previous token to be invalidated")
+	}
+
+	select {
+	case raw := <-notifier.verifications:
+		if _, exists := tokenRepo.tokens[domain.HashToken(raw)]; !exists {
+			t.Error("Expected new token to be stored")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected verification email to be sent")
+	}
+}
+
+func TestResendVerificationUseCase_Execute_AlreadyVerified(t *testing.T) {
+	// Arrange
+	verifiedAt := time.Now()
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com", Status: domain.UserStatusActive, EmailVerifiedAt: &verifiedAt}
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+	tokenRepo := newMockOneTimeTokenRepository()
+
+	useCase := usecase.NewResendVerificationUseCase(userRepo, tokenRepo, newMockNotifier(), usecase.EmailVerificationPolicy{TokenTTLMinutes: 60})
+
+	// Act
+	err := useCase.Execute(context.Background(), usecase.ResendVerificationRequest{Email: user.Email})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(tokenRepo.tokens) != 0 {
+		t.Errorf("Expected no tokens for verified user, got %d", len(tokenRepo.tokens))
+	}
+}
+
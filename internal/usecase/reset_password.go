@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+)
+
+type ResetPasswordUseCase struct {
+	userRepo          domain.UserRepository
+	oneTimeTokenRepo  domain.OneTimeTokenRepository
+	refreshTokenRepo  domain.RefreshTokenRepository
+	revocationStore   domain.TokenRevocationStore
+	eventPublisher    domain.EventPublisher
+	passwordHasher    domain.PasswordHasher
+	passwordValidator *PasswordValidator
+	passwordHistory   *PasswordHistory
+}
+
+func NewResetPasswordUseCase(
+	userRepo domain.UserRepository,
+	oneTimeTokenRepo domain.OneTimeTokenRepository,
+	refreshTokenRepo domain.RefreshTokenRepository,
+	revocationStore domain.TokenRevocationStore,
+	eventPublisher domain.EventPublisher,
+	passwordHasher domain.PasswordHasher,
+	passwordValidator *PasswordValidator,
+	passwordHistory *PasswordHistory,
+) *ResetPasswordUseCase {
+	return &ResetPasswordUseCase{
+		userRepo:          userRepo,
+		oneTimeTokenRepo:  oneTimeTokenRepo,
+		refreshTokenRepo:  refreshTokenRepo,
+		revocationStore:   revocationStore,
+		eventPublisher:    eventPublisher,
+		passwordHasher:    passwordHasher,
+		passwordValidator: passwordValidator,
+		passwordHistory:   passwordHistory,
+	}
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+// Execute consume el token, guarda la nueva contraseña e invalida los demás
+// tokens de restablecimiento y todas las sesiones del usuario.
+func (uc *ResetPasswordUseCase) Execute(ctx context.Context, req ResetPasswordRequest) error {
+	token, err := uc.oneTimeTokenRepo.FindByHash(ctx, domain.TokenPurposePasswordReset, domain.HashToken(req.Token))
+	if err != nil || token.UsedAt != nil || token.IsExpired(time.Now()) {
+		return errors.NewErrorWithCode(400, "Token inválido o expirado", errors.ErrInvalidOneTimeToken)
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, token.UserID.String())
+	if err != nil {
+		return errors.NewErrorWithCode(400, "Token inválido o expirado", errors.ErrInvalidOneTimeToken)
+	}
+
+	if err := uc.passwordValidator.Validate(ctx, req.NewPassword, user.Email, user.Name); err != nil {
+		return err
+	}
+
+	if err := uc.passwordHistory.CheckReuse(ctx, user, req.NewPassword); err != nil {
+		return err
+	}
+
+	marked, err := uc.oneTimeTokenRepo.MarkUsed(ctx, token.ID.String())
+	if err != nil {
+		return errors.NewErrorWithCode(500, "Error al consumir token", err)
+	}
+	if !marked {
+		return errors.NewErrorWithCode(400, "Token inválido o expirado", errors.ErrInvalidOneTimeToken)
+	}
+
+	if err := user.HashPassword(uc.passwordHasher, req.NewPassword); err != nil {
+		return errors.NewErrorWithCode(500, "Error al procesar contraseña", err)
+	}
+	now := time.Now()
+	user.PasswordChangedAt = &now
+
+	if err := uc.userRepo.Update(ctx, user, "password", "password_changed_at"); err != nil {
+		return errors.NewErrorWithCode(500, "Error al actualizar contraseña", err)
+	}
+
+	if err := uc.passwordHistory.Record(ctx, user); err != nil {
+		return err
+	}
+
+	if err := uc.oneTimeTokenRepo.InvalidateForUser(ctx, user.ID.String(), domain.TokenPurposePasswordReset); err != nil {
+		return errors.NewErrorWithCode(500, "Error al invalidar tokens", err)
+	}
+
+	if err := revokeUserSessions(ctx, uc.refreshTokenRepo, uc.revocationStore, user.ID.String()); err != nil {
+		return err
+	}
+
+	go func() {
+		eventCtx := eventContext(ctx)
+		uc.eventPublisher.PublishPasswordChanged(
+			eventCtx,
+			user.ID.String(),
+			user.UpdatedAt.Unix(),
+		)
+	}()
+
+	return nil
+}
+
@@ -0,0 +1,113 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/usecase"
+	"user-service/pkg/errors"
+)
+
+func seedResetToken(t *testing.T, repo *mockOneTimeTokenRepository, user *domain.User, ttl time.Duration) (string, *domain.OneTimeToken) {
+	raw, token, err := domain.NewOneTimeToken(user.ID, domain.TokenPurposePasswordReset, ttl)
+	if err != nil {
+		t.Fatalf("Expected no error generating token, got %v", err)
+	}
+	repo.Create(context.Background(), token)
+	return raw, token
+}
+
+func TestResetPasswordUseCase_Execute_Success(t *testing.T) {
+	// Arrange
+	user := newPasswordUser(t, "password123")
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+	tokenRepo := newMockOneTimeTokenRepository()
+	refreshRepo := newMockRefreshTokenRepository()
+	revocationStore := newMockRevocationStore()
+
+	raw, _ := seedResetToken(t, tokenRepo, user, time.Hour)
+	_, other := seedResetToken(t, tokenRepo, user, time.Hour)
+	session := seedRefreshTokenForUser(refreshRepo, "refresh-1", user.ID, time.Now().Add(time.Hour))
+
+	useCase := usecase.NewResetPasswordUseCase(userRepo, tokenRepo, refreshRepo, revocationStore, &mockEventPublisher{}, &mockPasswordHasher{}, newPasswordValidator(), newPasswordHistory())
+
+	// Act
+	err := useCase.Execute(context.Background(), usecase.ResetPasswordRequest{
+		Token:       raw,
+		NewPassword: "newpassword456",
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !user.VerifyPassword(&mockPasswordHasher{}, "newpassword456") {
+		t.Error("Expected new password to be stored")
+	}
+
+	if other.UsedAt == nil {
+		t.Error("Expected outstanding reset tokens to be invalidated")
+	}
+
+	if session.RevokedAt == nil {
+		t.Error("Expected sessions to be revoked")
+	}
+
+	// El mismo token no puede usarse dos veces
+	err = useCase.Execute(context.Background(), usecase.ResetPasswordRequest{
+		Token:       raw,
+		NewPassword: "anotherpassword789",
+	})
+	if errWithCode, ok := err.(*errors.ErrorWithCode); !ok || errWithCode.Code != 400 {
+		t.Fatalf("Expected 400 on reuse, got %v", err)
+	}
+}
+
+func TestResetPasswordUseCase_Execute_ExpiredToken(t *testing.T) {
+	// Arrange
+	user := newPasswordUser(t, "password123")
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+	tokenRepo := newMockOneTimeTokenRepository()
+
+	raw, _ := seedResetToken(t, tokenRepo, user, -time.Minute)
+
+	useCase := usecase.NewResetPasswordUseCase(userRepo, tokenRepo, newMockRefreshTokenRepository(), newMockRevocationStore(), &mockEventPublisher{}, &mockPasswordHasher{}, newPasswordValidator(), newPasswordHistory())
+
+	// Act
+	err := useCase.Execute(context.Background(), usecase.ResetPasswordRequest{
+		Token:       raw,
+		NewPassword: "newpassword456",
+	})
+
+	// Assert
+	if errWithCode, ok := err.(*errors.ErrorWithCode); !ok || errWithCode.Code != 400 {
+		t.Fatalf("Expected 400 error, got %v", err)
+	}
+
+	if !user.VerifyPassword(&mockPasswordHasher{}, "password123") {
+		t.Error("Expected password to remain unchanged")
+	}
+}
+
+func TestResetPasswordUseCase_Execute_UnknownToken(t *testing.T) {
+	// Arrange
+	user := newPasswordUser(t, "password123")
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+
+	useCase := usecase.NewResetPasswordUseCase(userRepo, newMockOneTimeTokenRepository(), newMockRefreshTokenRepository(), newMockRevocationStore(), &mockEventPublisher{}, &mockPasswordHasher{}, newPasswordValidator(), newPasswordHistory())
+
+	// Act
+	err := useCase.Execute(context.Background(), usecase.ResetPasswordRequest{
+		Token:       "not-a-token",
+		NewPassword: "newpassword456",
+	})
+
+	// Assert
+	if errWithCode, ok := err.(*errors.ErrorWithCode); !ok || errWithCode.Code != 400 {
+		t.Fatalf("Expected 400 error, got %v", err)
+	}
+}
+
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+)
+
+type RevokeAPIKeyUseCase struct {
+	apiKeyRepo domain.APIKeyRepository
+}
+
+func NewRevokeAPIKeyUseCase(apiKeyRepo domain.APIKeyRepository) *RevokeAPIKeyUseCase {
+	return &RevokeAPIKeyUseCase{
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+type RevokeAPIKeyRequest struct {
+	UserID   string `json:"-"`
+	APIKeyID string `json:"-"`
+}
+
+// Execute revoca la key de inmediato. Las keys de otros usuarios responden
+// 404, igual que las inexistentes.
+func (uc *RevokeAPIKeyUseCase) Execute(ctx context.Context, req RevokeAPIKeyRequest) error {
+	key, err := uc.apiKeyRepo.FindByID(ctx, req.APIKeyID)
+	if err != nil || key.UserID.String() != req.UserID || key.RevokedAt != nil {
+		return errors.NewErrorWithCode(404, "API key no encontrada", errors.ErrAPIKeyNotFound)
+	}
+
+	if err := uc.apiKeyRepo.Revoke(ctx, req.APIKeyID); err != nil {
+		return errors.NewErrorWithCode(500, "Error al revocar API key", err)
+	}
+
+	return nil
+}
+
@@ -0,0 +1,57 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"user-service/internal/domain"
+	"user-service/internal/usecase"
+	"github.com/google/uuid"
+)
+
+func TestRevokeAPIKeyUseCase_Execute(t *testing.T) {
+	// Arrange
+	user, _ := newRoleUser(domain.RoleUser)
+	apiKeyRepo := newMockAPIKeyRepository()
+	_, key := seedAPIKey(t, apiKeyRepo, user, nil, nil)
+
+	useCase := usecase.NewRevokeAPIKeyUseCase(apiKeyRepo)
+
+	// Act
+	err := useCase.Execute(context.Background(), usecase.RevokeAPIKeyRequest{
+		UserID:   user.ID.String(),
+		APIKeyID: key.ID.String(),
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if key.RevokedAt == nil {
+		t.Error("Expected key to be revoked")
+	}
+}
+
+func TestRevokeAPIKeyUseCase_Execute_KeyOfAnotherUser(t *testing.T) {
+	// Arrange
+	user, _ := newRoleUser(domain.RoleUser)
+	apiKeyRepo := newMockAPIKeyRepository()
+	_, key := seedAPIKey(t, apiKeyRepo, user, nil, nil)
+
+	useCase := usecase.NewRevokeAPIKeyUseCase(apiKeyRepo)
+
+	// Act
+	err := useCase.Execute(context.Background(), usecase.RevokeAPIKeyRequest{
+		UserID:   uuid.NewString(),
+		APIKeyID: key.ID.String(),
+	})
+
+	// Assert
+	assertLoginCode(t, err, 404)
+
+	if key.RevokedAt != nil {
+		t.Error("Expected key of another user to remain active")
+	}
+}
+
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+)
+
+type RevokeSessionUseCase struct {
+	sessionRepo      domain.SessionRepository
+	refreshTokenRepo domain.RefreshTokenRepository
+	revocationStore  domain.TokenRevocationStore
+}
+
+func NewRevokeSessionUseCase(
+	sessionRepo domain.SessionRepository,
+	refreshTokenRepo domain.RefreshTokenRepository,
+	revocationStore domain.TokenRevocationStore,
+) *RevokeSessionUseCase {
+	return &RevokeSessionUseCase{
+		sessionRepo:      sessionRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		revocationStore:  revocationStore,
+	}
+}
+
+type RevokeSessionRequest struct {
+	Principal *domain.Principal `json:"-"`
+	SessionID string            `json:"-"`
+}
+
+// Execute cierra una sesión del usuario: revoca sus refresh tokens y rechaza
+// desde ya los access tokens emitidos para ella. Las sesiones de otros
+// usuarios responden 404, igual que las inexistentes.
+func (uc *RevokeSessionUseCase) Execute(ctx context.Context, req RevokeSessionRequest) error {
+	session, err := uc.sessionRepo.FindByID(ctx, req.SessionID)
+	if err != nil || session.UserID.String() != req.Principal.UserID || session.RevokedAt != nil {
+		return errors.NewErrorWithCode(404, "Sesión no encontrada", errors.ErrSessionNotFound)
+	}
+
+	if err := uc.refreshTokenRepo.RevokeFamily(ctx, req.SessionID); err != nil {
+		return errors.NewErrorWithCode(500, "Error al cerrar sesión", err)
+	}
+
+	if err := uc.revocationStore.RevokeSession(ctx, req.SessionID); err != nil {
+		return errors.NewErrorWithCode(500, "Error al cerrar sesión", err)
+	}
+
+	return nil
+}
+
@@ -0,0 +1,67 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/usecase"
+	"github.com/google/uuid"
+)
+
+func TestRevokeSessionUseCase_Execute(t *testing.T) {
+	// Arrange
+	userID := uuid.New()
+	sessionRepo := newMockSessionRepository()
+	refreshRepo := newMockRefreshTokenRepository()
+	revocationStore := newMockRevocationStore()
+	session := seedSession(sessionRepo, userID)
+	stored := seedRefreshTokenForUser(refreshRepo, "refresh-1", userID, time.Now().Add(time.Hour))
+	stored.FamilyID = session.ID
+
+	useCase := usecase.NewRevokeSessionUseCase(sessionRepo, refreshRepo, revocationStore)
+
+	// Act
+	err := useCase.Execute(context.Background(), usecase.RevokeSessionRequest{
+		Principal: &domain.Principal{UserID: userID.String(), SessionID: uuid.NewString()},
+		SessionID: session.ID.String(),
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if stored.RevokedAt == nil {
+		t.Error("Expected session refresh tokens to be revoked")
+	}
+
+	sessionToken := &domain.Principal{UserID: userID.String(), SessionID: session.ID.String(), IssuedAt: time.Now()}
+	if revoked, _ := revocationStore.IsRevoked(context.Background(), sessionToken); !revoked {
+		t.Error("Expected access tokens of the session to be rejected")
+	}
+}
+
+func TestRevokeSessionUseCase_Execute_SessionOfAnotherUser(t *testing.T) {
+	// Arrange
+	sessionRepo := newMockSessionRepository()
+	revocationStore := newMockRevocationStore()
+	session := seedSession(sessionRepo, uuid.New())
+
+	useCase := usecase.NewRevokeSessionUseCase(sessionRepo, newMockRefreshTokenRepository(), revocationStore)
+
+	// Act
+	err := useCase.Execute(context.Background(), usecase.RevokeSessionRequest{
+		Principal: &domain.Principal{UserID: uuid.NewString()},
+		SessionID: session.ID.String(),
+	})
+
+	// Assert
+	assertLoginCode(t, err, 404)
+
+	if revocationStore.revokedSessions[session.ID.String()] {
+		t.Error("Expected session of another user to remain active")
+	}
+}
+
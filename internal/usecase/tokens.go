@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+type tokenPair struct {
+	accessToken  string
+	refreshToken string
+}
+
+// issueTokenPair emite un access token y un refresh token nuevo dentro de la
+// familia indicada. Cada login inicia una familia; cada rotación la continúa.
+// La familia identifica la sesión y viaja como sid en el access token; los
+// roles se leen del usuario en cada emisión para reflejar cambios de rol.
+func issueTokenPair(
+	ctx context.Context,
+	jwtService domain.JWTService,
+	refreshTokenRepo domain.RefreshTokenRepository,
+	user *domain.User,
+	familyID uuid.UUID,
+) (*tokenPair, error) {
+	accessToken, err := issueAccessToken(jwtService, user, familyID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, expiresAt, err := jwtService.GenerateRefreshToken()
+	if err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al generar refresh token", err)
+	}
+
+	stored := &domain.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: domain.HashToken(refreshToken),
+		ExpiresAt: expiresAt,
+	}
+	if err := refreshTokenRepo.Create(ctx, stored); err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al guardar refresh token", err)
+	}
+
+	return &tokenPair{
+		accessToken:  accessToken,
+		refreshToken: refreshToken,
+	}, nil
+}
+
+// SessionClient describe el cliente que abre una sesión.
+type SessionClient struct {
+	UserAgent string
+	IPAddress string
+}
+
+// startSession registra una sesión nueva y emite su primer par de tokens.
+func startSession(
+	ctx context.Context,
+	jwtService domain.JWTService,
+	refreshTokenRepo domain.RefreshTokenRepository,
+	sessionRepo domain.SessionRepository,
+	user *domain.User,
+	client SessionClient,
+) (*tokenPair, error) {
+	now := time.Now()
+	session := &domain.Session{
+		ID:         uuid.New(),
+		UserID:     user.ID,
+		UserAgent:  client.UserAgent,
+		IPAddress:  client.IPAddress,
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}
+	if err := sessionRepo.Create(ctx, session); err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al crear sesión", err)
+	}
+
+	return issueTokenPair(ctx, jwtService, refreshTokenRepo, user, session.ID)
+}
+
+// issueAccessToken emite solo el access token de una sesión existente.
+func issueAccessToken(jwtService domain.JWTService, user *domain.User, sessionID string) (string, error) {
+	roles := user.Roles()
+	accessToken, err := jwtService.GenerateToken(domain.Principal{
+		UserID:    user.ID.String(),
+		SessionID: sessionID,
+		Roles:     roles,
+		Scopes:    domain.PermissionsForRoles(roles),
+	})
+	if err != nil {
+		return "", errors.NewErrorWithCode(500, "Error al generar token", err)
+	}
+	return accessToken, nil
+}
+
+// issueRestrictedToken emite un access token sin roles ni sesión, que solo se
+// acepta en el cambio de contraseña.
+func issueRestrictedToken(jwtService domain.JWTService, user *domain.User) (string, error) {
+	accessToken, err := jwtService.GenerateToken(domain.Principal{
+		UserID: user.ID.String(),
+		Scopes: []string{domain.ScopePasswordChange},
+	})
+	if err != nil {
+		return "", errors.NewErrorWithCode(500, "Error al generar token", err)
+	}
+	return accessToken, nil
+}
+
@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+)
+
+type UnlockUserUseCase struct {
+	userRepo         domain.UserRepository
+	loginAttemptRepo domain.LoginAttemptRepository
+}
+
+func NewUnlockUserUseCase(
+	userRepo domain.UserRepository,
+	loginAttemptRepo domain.LoginAttemptRepository,
+) *UnlockUserUseCase {
+	return &UnlockUserUseCase{
+		userRepo:         userRepo,
+		loginAttemptRepo: loginAttemptRepo,
+	}
+}
+
+// Execute levanta el bloqueo por logins fallidos de la cuenta. Los contadores
+// por IP no se tocan.
+func (uc *UnlockUserUseCase) Execute(ctx context.Context, userID string) (*GetUserResponse, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, errors.NewErrorWithCode(404, "Usuario no encontrado", errors.ErrUserNotFound)
+	}
+
+	if err := uc.loginAttemptRepo.Reset(ctx, domain.AccountAttemptKey(user.Email)); err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al desbloquear usuario", err)
+	}
+
+	return &GetUserResponse{
+		User: toUserDTO(user),
+	}, nil
+}
+
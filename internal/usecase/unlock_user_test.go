@@ -0,0 +1,62 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/usecase"
+	"user-service/pkg/errors"
+	"github.com/google/uuid"
+)
+
+func TestUnlockUserUseCase_Execute_ResetsAccountCounter(t *testing.T) {
+	// Arrange
+	user := &domain.User{ID: uuid.New(), Email: "Test@Example.com", Status: domain.UserStatusActive}
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+	attemptRepo := newMockLoginAttemptRepository()
+
+	lockedUntil := time.Now().Add(time.Hour)
+	accountKey := domain.AccountAttemptKey(user.Email)
+	ipKey := domain.IPAttemptKey("10.0.0.1")
+	attemptRepo.attempts[accountKey] = &domain.LoginAttempt{Key: accountKey, Failures: 5, LockedUntil: &lockedUntil}
+	attemptRepo.attempts[ipKey] = &domain.LoginAttempt{Key: ipKey, Failures: 5, LockedUntil: &lockedUntil}
+
+	useCase := usecase.NewUnlockUserUseCase(userRepo, attemptRepo)
+
+	// Act
+	response, err := useCase.Execute(context.Background(), user.ID.String())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if response.User.ID != user.ID.String() {
+		t.Errorf("Expected user %s, got %s", user.ID, response.User.ID)
+	}
+
+	if _, exists := attemptRepo.attempts[accountKey]; exists {
+		t.Error("Expected account lock to be cleared")
+	}
+
+	if _, exists := attemptRepo.attempts[ipKey]; !exists {
+		t.Error("Expected IP counter to remain")
+	}
+}
+
+func TestUnlockUserUseCase_Execute_UserNotFound(t *testing.T) {
+	// Arrange
+	userRepo := &mockUserRepository{users: make(map[string]*domain.User)}
+	useCase := usecase.NewUnlockUserUseCase(userRepo, newMockLoginAttemptRepository())
+
+	// Act
+	_, err := useCase.Execute(context.Background(), uuid.NewString())
+
+	// Assert
+	if errWithCode, ok := err.(*errors.ErrorWithCode); !ok || errWithCode.Code != 404 {
+		t.Fatalf("Expected 404 error, got %v", err)
+	}
+}
+
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+)
+
+type UpdateProfileUseCase struct {
+	userRepo       domain.UserRepository
+	pldService     domain.PLDService
+	eventPublisher domain.EventPublisher
+}
+
+func NewUpdateProfileUseCase(
+	userRepo domain.UserRepository,
+	pldService domain.PLDService,
+	eventPublisher domain.EventPublisher,
+) *UpdateProfileUseCase {
+	return &UpdateProfileUseCase{
+		userRepo:       userRepo,
+		pldService:     pldService,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// UpdateProfileRequest es una actualización parcial: los campos nil no cambian.
+type UpdateProfileRequest struct {
+	UserID string  `json:"-"`
+	Name   *string `json:"name"`
+}
+
+func (uc *UpdateProfileUseCase) Execute(ctx context.Context, req UpdateProfileRequest) (*GetUserResponse, error) {
+	user, err := uc.userRepo.FindByID(ctx, req.UserID)
+	if err != nil {
+		return nil, errors.NewErrorWithCode(404, "Usuario no encontrado", errors.ErrUserNotFound)
+	}
+
+	if req.Name == nil {
+		return &GetUserResponse{User: toUserDTO(user)}, nil
+	}
+	name := strings.TrimSpace(*req.Name)
+	if name == user.Name {
+		return &GetUserResponse{User: toUserDTO(user)}, nil
+	}
+
+	// Se valida antes de consultar PLD para no enviarle datos inválidos
+	updated := *user
+	updated.Name = name
+	if err := updated.Validate(); err != nil {
+		return nil, errors.NewErrorWithCode(400, "Datos inválidos", err)
+	}
+
+	firstName, lastName := splitName(name)
+	inBlacklist, err := uc.pldService.CheckBlacklist(ctx, firstName, lastName, user.Email)
+	if err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al verificar PLD", err)
+	}
+	if inBlacklist {
+		return nil, errors.NewErrorWithCode(403, "Usuario en lista negra", errors.ErrUserInBlacklist)
+	}
+
+	user.Name = name
+
+	if err := uc.userRepo.Update(ctx, user, "name"); err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al actualizar usuario", err)
+	}
+
+	go func() {
+		eventCtx := eventContext(ctx)
+		uc.eventPublisher.PublishUserUpdated(
+			eventCtx,
+			user.ID.String(),
+			user.Email,
+			user.UpdatedAt.Unix(),
+		)
+	}()
+
+	return &GetUserResponse{
+		User: toUserDTO(user),
+	}, nil
+}
+
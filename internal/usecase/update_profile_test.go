@@ -0,0 +1,150 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"user-service/internal/domain"
+	"user-service/internal/usecase"
+	"user-service/pkg/errors"
+	"github.com/google/uuid"
+)
+
+func newProfileUser() *domain.User {
+	return &domain.User{
+		ID:       uuid.New(),
+		Email:    "test@example.com",
+		Password: "hashedpassword",
+		Name:     "Test User",
+		Role:     domain.RoleUser,
+		Status:   domain.UserStatusActive,
+	}
+}
+
+func TestUpdateProfileUseCase_Execute_Success(t *testing.T) {
+	// Arrange
+	user := newProfileUser()
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+	pldService := &mockPLDService{blacklist: make(map[string]bool)}
+	useCase := usecase.NewUpdateProfileUseCase(userRepo, pldService, &mockEventPublisher{})
+
+	name := "  Nuevo Nombre  "
+
+	// Act
+	response, err := useCase.Execute(context.Background(), usecase.UpdateProfileRequest{
+		UserID: user.ID.String(),
+		Name:   &name,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if response.User.Name != "Nuevo Nombre" {
+		t.Errorf("Expected name 'Nuevo Nombre', got %s", response.User.Name)
+	}
+
+	if userRepo.users[user.Email].Name != "Nuevo Nombre" {
+		t.Errorf("Expected stored name to be updated, got %s", userRepo.users[user.Email].Name)
+	}
+}
+
+func TestUpdateProfileUseCase_Execute_NameInBlacklist(t *testing.T) {
+	// Arrange
+	user := newProfileUser()
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+	pldService := &mockPLDService{blacklist: map[string]bool{user.Email: true}}
+	useCase := usecase.NewUpdateProfileUseCase(userRepo, pldService, &mockEventPublisher{})
+
+	name := "Nuevo Nombre"
+
+	// Act
+	_, err := useCase.Execute(context.Background(), usecase.UpdateProfileRequest{
+		UserID: user.ID.String(),
+		Name:   &name,
+	})
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	appErr, ok := err.(*errors.ErrorWithCode)
+	if !ok || appErr.Code != 403 {
+		t.Fatalf("Expected 403 AppError, got %v", err)
+	}
+
+	if user.Name != "Test User" {
+		t.Errorf("Expected name to remain unchanged, got %s", user.Name)
+	}
+}
+
+func TestUpdateProfileUseCase_Execute_NoChangesSkipsPLD(t *testing.T) {
+	// Arrange
+	user := newProfileUser()
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+	pldService := &mockPLDService{blacklist: map[string]bool{user.Email: true}}
+	useCase := usecase.NewUpdateProfileUseCase(userRepo, pldService, &mockEventPublisher{})
+
+	name := "Test User"
+
+	// Act
+	response, err := useCase.Execute(context.Background(), usecase.UpdateProfileRequest{
+		UserID: user.ID.String(),
+		Name:   &name,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if response.User.Name != "Test User" {
+		t.Errorf("Expected name 'Test User', got %s", response.User.Name)
+	}
+}
+
+func TestUpdateProfileUseCase_Execute_InvalidNameSkipsPLD(t *testing.T) {
+	// Arrange - si se consultara PLD, el email en lista negra daría 403
+	user := newProfileUser()
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+	pldService := &mockPLDService{blacklist: map[string]bool{user.Email: true}}
+	useCase := usecase.NewUpdateProfileUseCase(userRepo, pldService, &mockEventPublisher{})
+
+	name := "   "
+
+	// Act
+	_, err := useCase.Execute(context.Background(), usecase.UpdateProfileRequest{
+		UserID: user.ID.String(),
+		Name:   &name,
+	})
+
+	// Assert
+	appErr, ok := err.(*errors.ErrorWithCode)
+	if !ok || appErr.Code != 400 {
+		t.Fatalf("Expected 400 AppError, got %v", err)
+	}
+}
+
+func TestUpdateProfileUseCase_Execute_UserNotFound(t *testing.T) {
+	// Arrange
+	userRepo := &mockUserRepository{users: make(map[string]*domain.User)}
+	pldService := &mockPLDService{blacklist: make(map[string]bool)}
+	useCase := usecase.NewUpdateProfileUseCase(userRepo, pldService, &mockEventPublisher{})
+
+	name := "Nuevo Nombre"
+
+	// Act
+	_, err := useCase.Execute(context.Background(), usecase.UpdateProfileRequest{
+		UserID: uuid.NewString(),
+		Name:   &name,
+	})
+
+	// Assert
+	appErr, ok := err.(*errors.ErrorWithCode)
+	if !ok || appErr.Code != 404 {
+		t.Fatalf("Expected 404 AppError, got %v", err)
+	}
+}
+
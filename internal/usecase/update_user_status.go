@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+)
+
+type UpdateUserStatusUseCase struct {
+	userRepo         domain.UserRepository
+	refreshTokenRepo domain.RefreshTokenRepository
+	revocationStore  domain.TokenRevocationStore
+}
+
+func NewUpdateUserStatusUseCase(
+	userRepo domain.UserRepository,
+	refreshTokenRepo domain.RefreshTokenRepository,
+	revocationStore domain.TokenRevocationStore,
+) *UpdateUserStatusUseCase {
+	return &UpdateUserStatusUseCase{
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		revocationStore:  revocationStore,
+	}
+}
+
+type UpdateUserStatusRequest struct {
+	ActorID string `json:"-"`
+	UserID  string `json:"-"`
+	Status  string `json:"status" validate:"required,oneof=active suspended"`
+}
+
+// Execute suspende o reactiva un usuario. Al suspender se cierran todas sus
+// sesiones para que el cambio tenga efecto inmediato.
+func (uc *UpdateUserStatusUseCase) Execute(ctx context.Context, req UpdateUserStatusRequest) (*GetUserResponse, error) {
+	if req.Status != domain.UserStatusActive && req.Status != domain.UserStatusSuspended {
+		return nil, errors.NewErrorWithCode(400, "Estado inválido", nil)
+	}
+
+	if req.ActorID == req.UserID {
+		return nil, errors.NewErrorWithCode(400, "No puede cambiar el estado de su propio usuario", errors.ErrForbidden)
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, req.UserID)
+	if err != nil {
+		return nil, errors.NewErrorWithCode(404, "Usuario no encontrado", errors.ErrUserNotFound)
+	}
+
+	if user.Status != req.Status {
+		user.Status = req.Status
+		if err := uc.userRepo.Update(ctx, user, "status"); err != nil {
+			return nil, errors.NewErrorWithCode(500, "Error al actualizar usuario", err)
+		}
+	}
+
+	if user.IsSuspended() {
+		if err := revokeUserSessions(ctx, uc.refreshTokenRepo, uc.revocationStore, req.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	return &GetUserResponse{
+		User: toUserDTO(user),
+	}, nil
+}
+
+func revokeUserSessions(
+	ctx context.Context,
+	refreshTokenRepo domain.RefreshTokenRepository,
+	revocationStore domain.TokenRevocationStore,
+	userID string,
+) error {
+	if err := revocationStore.RevokeAllForUser(ctx, userID, time.Now()); err != nil {
+		return errors.NewErrorWithCode(500, "Error al cerrar sesiones", err)
+	}
+	if err := refreshTokenRepo.RevokeAllForUser(ctx, userID); err != nil {
+		return errors.NewErrorWithCode(500, "Error al cerrar sesiones", err)
+	}
+	return nil
+}
+
@@ -0,0 +1,86 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/usecase"
+	"user-service/pkg/errors"
+	"github.com/google/uuid"
+)
+
+func TestUpdateUserStatusUseCase_Execute_SuspendRevokesSessions(t *testing.T) {
+	// Arrange
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com", Status: domain.UserStatusActive}
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+	refreshRepo := newMockRefreshTokenRepository()
+	revocationStore := newMockRevocationStore()
+	stored := seedRefreshTokenForUser(refreshRepo, "refresh-1", user.ID, time.Now().Add(time.Hour))
+
+	useCase := usecase.NewUpdateUserStatusUseCase(userRepo, refreshRepo, revocationStore)
+
+	// Act
+	response, err := useCase.Execute(context.Background(), usecase.UpdateUserStatusRequest{
+		ActorID: uuid.NewString(),
+		UserID:  user.ID.String(),
+		Status:  domain.UserStatusSuspended,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if response.User.Status != domain.UserStatusSuspended {
+		t.Errorf("Expected status suspended, got %s", response.User.Status)
+	}
+
+	if stored.RevokedAt == nil {
+		t.Error("Expected refresh tokens to be revoked on suspension")
+	}
+
+	if _, ok := revocationStore.revokedBefore[user.ID.String()]; !ok {
+		t.Error("Expected access tokens to be revoked on suspension")
+	}
+}
+
+func TestUpdateUserStatusUseCase_Execute_CannotChangeOwnStatus(t *testing.T) {
+	// Arrange
+	user := &domain.User{ID: uuid.New(), Email: "admin@example.com", Status: domain.UserStatusActive}
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+
+	useCase := usecase.NewUpdateUserStatusUseCase(userRepo, newMockRefreshTokenRepository(), newMockRevocationStore())
+
+	// Act
+	_, err := useCase.Execute(context.Background(), usecase.UpdateUserStatusRequest{
+		ActorID: user.ID.String(),
+		UserID:  user.ID.String(),
+		Status:  domain.UserStatusSuspended,
+	})
+
+	// Assert
+	if errWithCode, ok := err.(*errors.ErrorWithCode); !ok || errWithCode.Code != 400 {
+		t.Errorf("Expected status code 400, got %v", err)
+	}
+}
+
+func TestUpdateUserStatusUseCase_Execute_UserNotFound(t *testing.T) {
+	// Arrange
+	userRepo := &mockUserRepository{users: make(map[string]*domain.User)}
+	useCase := usecase.NewUpdateUserStatusUseCase(userRepo, newMockRefreshTokenRepository(), newMockRevocationStore())
+
+	// Act
+	_, err := useCase.Execute(context.Background(), usecase.UpdateUserStatusRequest{
+		ActorID: uuid.NewString(),
+		UserID:  uuid.NewString(),
+		Status:  domain.UserStatusActive,
+	})
+
+	// Assert
+	if errWithCode, ok := err.(*errors.ErrorWithCode); !ok || errWithCode.Code != 404 {
+		t.Errorf("Expected status code 404, got %v", err)
+	}
+}
+
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+)
+
+// EmailVerificationPolicy controla la verificación de email. Con Required los
+// usuarios sin verificar no reciben tokens al registrarse ni pueden hacer login.
+type EmailVerificationPolicy struct {
+	Required        bool
+	TokenTTLMinutes int
+}
+
+// sendEmailVerification genera un token de verificación y lo entrega al
+// notificador en segundo plano.
+func sendEmailVerification(
+	ctx context.Context,
+	oneTimeTokenRepo domain.OneTimeTokenRepository,
+	notifier domain.Notifier,
+	user *domain.User,
+	ttl time.Duration,
+) error {
+	rawToken, token, err := domain.NewOneTimeToken(user.ID, domain.TokenPurposeEmailVerification, ttl)
+	if err != nil {
+		return errors.NewErrorWithCode(500, "Error al generar token", err)
+	}
+
+	if err := oneTimeTokenRepo.Create(ctx, token); err != nil {
+		return errors.NewErrorWithCode(500, "Error al guardar token", err)
+	}
+
+	go func() {
+		notifyCtx := context.Background()
+		notifier.SendEmailVerification(notifyCtx, user.Email, rawToken, token.ExpiresAt)
+	}()
+
+	return nil
+}
+
+type VerifyEmailUseCase struct {
+	userRepo         domain.UserRepository
+	oneTimeTokenRepo domain.OneTimeTokenRepository
+}
+
+func NewVerifyEmailUseCase(
+	userRepo domain.UserRepository,
+	oneTimeTokenRepo domain.OneTimeTokenRepository,
+) *VerifyEmailUseCase {
+	return &VerifyEmailUseCase{
+		userRepo:         userRepo,
+		oneTimeTokenRepo: oneTimeTokenRepo,
+	}
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+func (uc *VerifyEmailUseCase) Execute(ctx context.Context, req VerifyEmailRequest) error {
+	token, err := uc.oneTimeTokenRepo.FindByHash(ctx, domain.TokenPurposeEmailVerification, domain.HashToken(req.Token))
+	if err != nil || token.UsedAt != nil || token.IsExpired(time.Now()) {
+		return errors.NewErrorWithCode(400, "Token inválido o expirado", errors.ErrInvalidOneTimeToken)
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, token.UserID.String())
+	if err != nil {
+		return errors.NewErrorWithCode(400, "Token inválido o expirado", errors.ErrInvalidOneTimeToken)
+	}
+
+	marked, err := uc.oneTimeTokenRepo.MarkUsed(ctx, token.ID.String())
+	if err != nil {
+		return errors.NewErrorWithCode(500, "Error al consumir token", err)
+	}
+	if !marked {
+		return errors.NewErrorWithCode(400, "Token inválido o expirado", errors.ErrInvalidOneTimeToken)
+	}
+
+	if user.IsEmailVerified() {
+		return nil
+	}
+
+	now := time.Now()
+	user.EmailVerifiedAt = &now
+	if err := uc.userRepo.Update(ctx, user, "email_verified_at"); err != nil {
+		return errors.NewErrorWithCode(500, "Error al actualizar usuario", err)
+	}
+
+	if err := uc.oneTimeTokenRepo.InvalidateForUser(ctx, user.ID.String(), domain.TokenPurposeEmailVerification); err != nil {
+		return errors.NewErrorWithCode(500, "Error al invalidar tokens", err)
+	}
+
+	return nil
+}
+
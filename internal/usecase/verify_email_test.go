@@ -0,0 +1,65 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/usecase"
+	"user-service/pkg/errors"
+	"github.com/google/uuid"
+)
+
+func TestVerifyEmailUseCase_Execute_Success(t *testing.T) {
+	// Arrange
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com", Status: domain.UserStatusActive}
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+	tokenRepo := newMockOneTimeTokenRepository()
+
+	raw, token, _ := domain.NewOneTimeToken(user.ID, domain.TokenPurposeEmailVerification, time.Hour)
+	tokenRepo.Create(context.Background(), token)
+
+	useCase := usecase.NewVerifyEmailUseCase(userRepo, tokenRepo)
+
+	// Act
+	err := useCase.Execute(context.Background(), usecase.VerifyEmailRequest{Token: raw})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !user.IsEmailVerified() {
+		t.Error("Expected email to be verified")
+	}
+
+	if token.UsedAt == nil {
+		t.Error("Expected token to be consumed")
+	}
+}
+
+func TestVerifyEmailUseCase_Execute_WrongPurpose(t *testing.T) {
+	// Arrange
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com", Status: domain.UserStatusActive}
+	userRepo := &mockUserRepository{users: map[string]*domain.User{user.Email: user}}
+	tokenRepo := newMockOneTimeTokenRepository()
+
+	raw, token, _ := domain.NewOneTimeToken(user.ID, domain.TokenPurposePasswordReset, time.Hour)
+	tokenRepo.Create(context.Background(), token)
+
+	useCase := usecase.NewVerifyEmailUseCase(userRepo, tokenRepo)
+
+	// Act
+	err := useCase.Execute(context.Background(), usecase.VerifyEmailRequest{Token: raw})
+
+	// Assert
+	if errWithCode, ok := err.(*errors.ErrorWithCode); !ok || errWithCode.Code != 400 {
+		t.Fatalf("Expected 400 error, got %v", err)
+	}
+
+	if user.IsEmailVerified() {
+		t.Error("Expected email to remain unverified")
+	}
+}
+
@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/pkg/errors"
+)
+
+type VerifyMFAUseCase struct {
+	userRepo         domain.UserRepository
+	oneTimeTokenRepo domain.OneTimeTokenRepository
+	recoveryCodeRepo domain.RecoveryCodeRepository
+	refreshTokenRepo domain.RefreshTokenRepository
+	sessionRepo      domain.SessionRepository
+	loginAttemptRepo domain.LoginAttemptRepository
+	cipher           domain.SecretCipher
+	jwtService       domain.JWTService
+	lockout          domain.LockoutPolicy
+	rotationPolicy   domain.PasswordRotationPolicy
+}
+
+func NewVerifyMFAUseCase(
+	userRepo domain.UserRepository,
+	oneTimeTokenRepo domain.OneTimeTokenRepository,
+	recoveryCodeRepo domain.RecoveryCodeRepository,
+	refreshTokenRepo domain.RefreshTokenRepository,
+	sessionRepo domain.SessionRepository,
+	loginAttemptRepo domain.LoginAttemptRepository,
+	cipher domain.SecretCipher,
+	jwtService domain.JWTService,
+	lockout domain.LockoutPolicy,
+	rotationPolicy domain.PasswordRotationPolicy,
+) *VerifyMFAUseCase {
+	return &VerifyMFAUseCase{
+		userRepo:         userRepo,
+		oneTimeTokenRepo: oneTimeTokenRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		sessionRepo:      sessionRepo,
+		loginAttemptRepo: loginAttemptRepo,
+		cipher:           cipher,
+		jwtService:       jwtService,
+		lockout:          lockout,
+		rotationPolicy:   rotationPolicy,
+	}
+}
+
+// VerifyMFARequest admite un código TOTP o, en su lugar, un código de recuperación.
+type VerifyMFARequest struct {
+	MFAToken     string `json:"mfa_token"`
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+	IPAddress    string `json:"-"`
+	UserAgent    string `json:"-"`
+}
+
+// Execute completa el login iniciado en LoginUseCase. Un código incorrecto no
+// consume el desafío, pero cuenta como fallo con la misma política de bloqueo
+// que las contraseñas.
+func (uc *VerifyMFAUseCase) Execute(ctx context.Context, req VerifyMFARequest) (*LoginResponse, error) {
+	challenge, err := uc.oneTimeTokenRepo.FindByHash(ctx, domain.TokenPurposeMFAChallenge, domain.HashToken(req.MFAToken))
+	if err != nil || challenge.UsedAt != nil || challenge.IsExpired(time.Now()) {
+		return nil, errors.NewErrorWithCode(401, "Token inválido o expirado", errors.ErrInvalidOneTimeToken)
+	}
+
+	attemptKey := domain.MFAAttemptKey(challenge.UserID.String())
+	if err := checkThrottle(ctx, uc.loginAttemptRepo, attemptKey, uc.lockout); err != nil {
+		return nil, err
+	}
+	attempt, err := reserveAttempt(ctx, uc.loginAttemptRepo, attemptKey, uc.lockout)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, challenge.UserID.String())
+	if err != nil {
+		return nil, errors.NewErrorWithCode(401, "Token inválido o expirado", errors.ErrInvalidOneTimeToken)
+	}
+
+	if user.IsSuspended() {
+		return nil, errors.NewErrorWithCode(403, "Usuario suspendido", errors.ErrUserSuspended)
+	}
+
+	valid, err := uc.verifySecondFactor(ctx, user, req)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		if _, err := lockIfExhausted(ctx, uc.loginAttemptRepo, attempt, uc.lockout); err != nil {
+			return nil, err
+		}
+		return nil, errors.NewErrorWithCode(401, "Código MFA inválido", errors.ErrInvalidMFACode)
+	}
+
+	marked, err := uc.oneTimeTokenRepo.MarkUsed(ctx, challenge.ID.String())
+	if err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al consumir desafío MFA", err)
+	}
+	if !marked {
+		return nil, errors.NewErrorWithCode(401, "Token inválido o expirado", errors.ErrInvalidOneTimeToken)
+	}
+
+	if err := uc.loginAttemptRepo.Reset(ctx, attemptKey); err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al registrar login", err)
+	}
+
+	if err := uc.userRepo.Update(ctx, user, "totp_last_step"); err != nil {
+		return nil, errors.NewErrorWithCode(500, "Error al actualizar usuario", err)
+	}
+
+	client := SessionClient{UserAgent: req.UserAgent, IPAddress: req.IPAddress}
+	return completeLogin(ctx, uc.jwtService, uc.refreshTokenRepo, uc.sessionRepo, uc.rotationPolicy, user, client)
+}
+
+func (uc *VerifyMFAUseCase) verifySecondFactor(ctx context.Context, user *domain.User, req VerifyMFARequest) (bool, error) {
+	if req.RecoveryCode != "" {
+		consumed, err := uc.recoveryCodeRepo.Consume(ctx, user.ID.String(), domain.HashToken(normalizeRecoveryCode(req.RecoveryCode)))
+		if err != nil {
+			return false, errors.NewErrorWithCode(500, "Error al verificar código de recuperación", err)
+		}
+		return consumed, nil
+	}
+
+	valid, err := verifyTOTPCode(uc.cipher, user, req.Code, time.Now())
+	if err != nil {
+		return false, errors.NewErrorWithCode(500, "Error al verificar código", err)
+	}
+	return valid, nil
+}
+
@@ -0,0 +1,229 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-service/internal/domain"
+	"user-service/internal/usecase"
+	"user-service/pkg/errors"
+)
+
+type mfaFixture struct {
+	user             *domain.User
+	secret           string
+	userRepo         *mockUserRepository
+	oneTimeTokenRepo *mockOneTimeTokenRepository
+	recoveryCodeRepo *mockRecoveryCodeRepository
+	attemptRepo      *mockLoginAttemptRepository
+	useCase          *usecase.VerifyMFAUseCase
+}
+
+// newMFAFixture prepara un usuario con MFA activo y el caso de uso que
+// completa su login.
+func newMFAFixture(t *testing.T, lockout domain.LockoutPolicy) *mfaFixture {
+	user, secret := newEnrolledUser(t)
+	user.MFAEnabled = true
+
+	f := &mfaFixture{
+		user:             user,
+		secret:           secret,
+		userRepo:         &mockUserRepository{users: map[string]*domain.User{user.Email: user}},
+		oneTimeTokenRepo: newMockOneTimeTokenRepository(),
+		recoveryCodeRepo: newMockRecoveryCodeRepository(),
+		attemptRepo:      newMockLoginAttemptRepository(),
+	}
+	f.useCase = usecase.NewVerifyMFAUseCase(
+		f.userRepo,
+		f.oneTimeTokenRepo,
+		f.recoveryCodeRepo,
+		newMockRefreshTokenRepository(),
+		newMockSessionRepository(),
+		f.attemptRepo,
+		&mockCipher{},
+		&mockJWTService{},
+		lockout,
+		domain.PasswordRotationPolicy{},
+	)
+	return f
+}
+
+// challenge inicia el login con contraseña y devuelve el token del desafío.
+func (f *mfaFixture) challenge(t *testing.T) string {
+	loginUseCase := usecase.NewLoginUseCase(
+		f.userRepo,
+		newMockRefreshTokenRepository(),
+		newMockSessionRepository(),
+		f.oneTimeTokenRepo,
+		newMockLoginAttemptRepository(),
+		&mockEventPublisher{},
+		&mockJWTService{},
+		&mockPasswordHasher{},
+		usecase.EmailVerificationPolicy{},
+		domain.LockoutPolicy{},
+		domain.LockoutPolicy{},
+		domain.PasswordRotationPolicy{},
+	)
+
+	response, err := loginUseCase.Execute(context.Background(), usecase.LoginRequest{
+		Email:    f.user.Email,
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error on login, got %v", err)
+	}
+	if !response.MFARequired || response.MFAToken == "" {
+		t.Fatalf("Expected MFA challenge, got %+v", response)
+	}
+	if response.Token != "" || response.RefreshToken != "" {
+		t.Fatal("Expected no session tokens before MFA")
+	}
+	return response.MFAToken
+}
+
+func TestVerifyMFAUseCase_Execute_TOTPSuccess(t *testing.T) {
+	// Arrange
+	f := newMFAFixture(t, domain.LockoutPolicy{})
+	mfaToken := f.challenge(t)
+
+	req := usecase.VerifyMFARequest{
+		MFAToken: mfaToken,
+		Code:     currentTOTPCode(t, f.secret),
+	}
+
+	// Act
+	response, err := f.useCase.Execute(context.Background(), req)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if response.Token == "" || response.RefreshToken == "" {
+		t.Fatal("Expected session tokens")
+	}
+
+	_, err = f.useCase.Execute(context.Background(), req)
+	errWithCode, ok := err.(*errors.ErrorWithCode)
+	if !ok || errWithCode.Code != 401 {
+		t.Fatalf("Expected challenge to be single-use, got %v", err)
+	}
+}
+
+func TestVerifyMFAUseCase_Execute_ReplayedCodeRejected(t *testing.T) {
+	// Arrange
+	f := newMFAFixture(t, domain.LockoutPolicy{})
+	code := currentTOTPCode(t, f.secret)
+
+	if _, err := f.useCase.Execute(context.Background(), usecase.VerifyMFARequest{MFAToken: f.challenge(t), Code: code}); err != nil {
+		t.Fatalf("Expected no error on first use, got %v", err)
+	}
+
+	// Act
+	_, err := f.useCase.Execute(context.Background(), usecase.VerifyMFARequest{MFAToken: f.challenge(t), Code: code})
+
+	// Assert
+	errWithCode, ok := err.(*errors.ErrorWithCode)
+	if !ok || errWithCode.Code != 401 {
+		t.Fatalf("Expected status code 401, got %v", err)
+	}
+}
+
+func TestVerifyMFAUseCase_Execute_WrongCodeKeepsChallenge(t *testing.T) {
+	// Arrange
+	f := newMFAFixture(t, domain.LockoutPolicy{})
+	mfaToken := f.challenge(t)
+
+	// Act
+	_, err := f.useCase.Execute(context.Background(), usecase.VerifyMFARequest{MFAToken: mfaToken, Code: "000000"})
+
+	// Assert
+	errWithCode, ok := err.(*errors.ErrorWithCode)
+	if !ok || errWithCode.Code != 401 {
+		t.Fatalf("Expected status code 401, got %v", err)
+	}
+
+	response, err := f.useCase.Execute(context.Background(), usecase.VerifyMFARequest{MFAToken: mfaToken, Code: currentTOTPCode(t, f.secret)})
+	if err != nil {
+		t.Fatalf("Expected challenge to remain usable, got %v", err)
+	}
+	if response.Token == "" {
+		t.Error("Expected session token")
+	}
+}
+
+func TestVerifyMFAUseCase_Execute_LockoutAfterMaxFailures(t *testing.T) {
+	// Arrange
+	f := newMFAFixture(t, domain.LockoutPolicy{MaxFailures: 3, LockoutDuration: time.Minute})
+	mfaToken := f.challenge(t)
+
+	for i := 0; i < 3; i++ {
+		f.useCase.Execute(context.Background(), usecase.VerifyMFARequest{MFAToken: mfaToken, Code: "000000"})
+	}
+
+	// Act
+	_, err := f.useCase.Execute(context.Background(), usecase.VerifyMFARequest{MFAToken: mfaToken, Code: currentTOTPCode(t, f.secret)})
+
+	// Assert
+	errWithCode, ok := err.(*errors.ErrorWithCode)
+	if !ok || errWithCode.Code != 429 {
+		t.Fatalf("Expected status code 429, got %v", err)
+	}
+}
+
+func TestVerifyMFAUseCase_Execute_RecoveryCode(t *testing.T) {
+	// Arrange
+	f := newMFAFixture(t, domain.LockoutPolicy{})
+	f.user.MFAEnabled = false
+	confirmUseCase := usecase.NewConfirmTOTPUseCase(f.userRepo, f.recoveryCodeRepo, &mockCipher{})
+	confirmed, err := confirmUseCase.Execute(context.Background(), usecase.ConfirmTOTPRequest{
+		UserID: f.user.ID.String(),
+		Code:   currentTOTPCode(t, f.secret),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error confirming TOTP, got %v", err)
+	}
+	recoveryCode := confirmed.RecoveryCodes[0]
+
+	// Act
+	response, err := f.useCase.Execute(context.Background(), usecase.VerifyMFARequest{
+		MFAToken:     f.challenge(t),
+		RecoveryCode: recoveryCode,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if response.Token == "" {
+		t.Error("Expected session token")
+	}
+
+	_, err = f.useCase.Execute(context.Background(), usecase.VerifyMFARequest{
+		MFAToken:     f.challenge(t),
+		RecoveryCode: recoveryCode,
+	})
+	errWithCode, ok := err.(*errors.ErrorWithCode)
+	if !ok || errWithCode.Code != 401 {
+		t.Fatalf("Expected recovery code to be single-use, got %v", err)
+	}
+}
+
+func TestVerifyMFAUseCase_Execute_InvalidChallenge(t *testing.T) {
+	// Arrange
+	f := newMFAFixture(t, domain.LockoutPolicy{})
+
+	// Act
+	_, err := f.useCase.Execute(context.Background(), usecase.VerifyMFARequest{
+		MFAToken: "token-inexistente",
+		Code:     currentTOTPCode(t, f.secret),
+	})
+
+	// Assert
+	errWithCode, ok := err.(*errors.ErrorWithCode)
+	if !ok || errWithCode.Code != 401 {
+		t.Fatalf("Expected status code 401, got %v", err)
+	}
+}
+
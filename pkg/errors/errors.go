@@ -10,13 +10,33 @@ var (
 	ErrUserInBlacklist   = fmt.Errorf("usuario está en lista negra")
 	ErrUnauthorized      = fmt.Errorf("no autorizado")
 	ErrForbidden         = fmt.Errorf("acceso prohibido")
+	ErrInvalidRefreshToken = fmt.Errorf("refresh token inválido")
+	ErrRefreshTokenReused  = fmt.Errorf("refresh token reutilizado")
+	ErrUserSuspended       = fmt.Errorf("usuario suspendido")
+	ErrInvalidCurrentPassword = fmt.Errorf("contraseña actual incorrecta")
+	ErrInvalidOneTimeToken    = fmt.Errorf("token inválido o expirado")
+	ErrEmailNotVerified       = fmt.Errorf("email no verificado")
+	ErrTooManyAttempts        = fmt.Errorf("demasiados intentos fallidos")
+	ErrMFAAlreadyEnabled      = fmt.Errorf("MFA ya está activado")
+	ErrMFANotEnrolled         = fmt.Errorf("MFA no está enrolado")
+	ErrInvalidMFACode         = fmt.Errorf("código MFA inválido")
+	ErrPasswordExpired        = fmt.Errorf("contraseña expirada")
+	ErrSessionNotFound        = fmt.Errorf("sesión no encontrada")
+	ErrInvalidAPIKey          = fmt.Errorf("API key inválida")
+	ErrAPIKeyNotFound         = fmt.Errorf("API key no encontrada")
+	ErrInvalidClient          = fmt.Errorf("cliente inválido")
+	ErrInvalidScope           = fmt.Errorf("scope inválido")
+	ErrUnsupportedGrantType   = fmt.Errorf("grant_type no soportado")
+	ErrOAuthClientNotFound    = fmt.Errorf("cliente OAuth no encontrado")
 )
 
-// ErrorWithCode representa un error con código HTTP
+// ErrorWithCode representa un error con código HTTP. Details, si no es nil,
+// se serializa tal cual en la respuesta.
 type ErrorWithCode struct {
 	Code    int
 	Message string
 	Err     error
+	Details interface{}
 }
 
 func (e *ErrorWithCode) Error() string {
@@ -34,3 +54,12 @@ func NewErrorWithCode(code int, message string, err error) *ErrorWithCode {
 	}
 }
 
+func NewErrorWithDetails(code int, message string, err error, details interface{}) *ErrorWithCode {
+	return &ErrorWithCode{
+		Code:    code,
+		Message: message,
+		Err:     err,
+		Details: details,
+	}
+}
+
@@ -0,0 +1,76 @@
+package totp_test
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"user-service/pkg/totp"
+)
+
+// Vectores SHA1 del apéndice B de RFC 6238, truncados a 6 dígitos.
+func TestCode_RFC6238Vectors(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+	}
+
+	for _, tt := range tests {
+		// Act
+		got, err := totp.Code(secret, totp.Step(time.Unix(tt.unix, 0)))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("At %d expected %s, got %s", tt.unix, tt.want, got)
+		}
+	}
+}
+
+func TestValidate_Skew(t *testing.T) {
+	// Arrange
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	now := time.Now()
+	previous, _ := totp.Code(secret, totp.Step(now)-1)
+	stale, _ := totp.Code(secret, totp.Step(now)-3)
+
+	// Act
+	step, ok := totp.Validate(secret, previous, now, 1)
+	_, staleOK := totp.Validate(secret, stale, now, 1)
+
+	// Assert
+	if !ok || step != totp.Step(now)-1 {
+		t.Errorf("Expected previous step to be accepted, got step=%d ok=%v", step, ok)
+	}
+	if staleOK {
+		t.Error("Expected code outside skew window to be rejected")
+	}
+}
+
+func TestProvisioningURI(t *testing.T) {
+	// Act
+	uri := totp.ProvisioningURI("Crabi", "user@example.com", "JBSWY3DPEHPK3PXP")
+
+	// Assert
+	if !strings.HasPrefix(uri, "otpauth://totp/Crabi:user@example.com?") {
+		t.Errorf("Unexpected URI prefix: %s", uri)
+	}
+	if !strings.Contains(uri, "secret=JBSWY3DPEHPK3PXP") || !strings.Contains(uri, "issuer=Crabi") {
+		t.Errorf("Expected secret and issuer in URI: %s", uri)
+	}
+}
+